@@ -3,6 +3,7 @@ package comparators
 import (
 	"fmt"
 	"reflect"
+	"time"
 )
 
 // RegisterBuiltInComparators 注册内置比较器
@@ -33,6 +34,11 @@ func equal(a, b interface{}) bool {
 	if a == nil && b == nil {
 		return true
 	}
+	if ta, ok := a.(time.Time); ok {
+		if tb, ok := b.(time.Time); ok {
+			return ta.Equal(tb)
+		}
+	}
 	return reflect.DeepEqual(a, b)
 }
 
@@ -63,6 +69,14 @@ func lessThanOrEqual(a, b interface{}) bool {
 
 // compareNumeric 辅助函数，处理数值比较
 func compareNumeric(a, b interface{}, cmp func(float64, float64) bool) bool {
+	// 时间按先后顺序比较
+	if ta, ok := a.(time.Time); ok {
+		tb, ok := b.(time.Time)
+		if !ok {
+			return false
+		}
+		return cmp(float64(ta.Compare(tb)), 0)
+	}
 	fa, ok := toFloat64(a)
 	if !ok {
 		return false
@@ -4,6 +4,7 @@ import (
 	"math"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -264,3 +265,16 @@ func TestNumericComparators_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestComparators_Time(t *testing.T) {
+	earlier := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Hour)
+	sameInstant := earlier.In(time.FixedZone("UTC+8", 8*3600))
+
+	assert.True(t, greaterThan(later, earlier))
+	assert.False(t, greaterThan(earlier, later))
+	assert.True(t, lessThanOrEqual(earlier, sameInstant))
+	assert.True(t, greaterThanOrEqual(later, earlier))
+	assert.True(t, equal(earlier, sameInstant))
+	assert.False(t, greaterThan(later, 1.0))
+}
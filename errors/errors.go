@@ -36,6 +36,12 @@ type ValidationError struct {
 
 	// Param 相关的参数
 	Param string `json:"param,omitempty"`
+
+	// SchemaValue 失败关键字在schema中的原始约束值
+	SchemaValue interface{} `json:"schema,omitempty"`
+
+	// SchemaPath 产生错误的关键字在schema中的 JSON Pointer，例如 "/properties/age/minimum"
+	SchemaPath string `json:"schemaPath,omitempty"`
 }
 
 // Error 实现error接口
@@ -107,6 +113,22 @@ func (ve ValidationErrors) formatJSON() string {
 	return string(bytes)
 }
 
+// FormattedErrors 包装验证错误集合，Error() 按指定模式输出
+type FormattedErrors struct {
+	Errors ValidationErrors
+	Mode   FormattingMode
+}
+
+// Error 实现error接口
+func (fe *FormattedErrors) Error() string {
+	return fe.Errors.FormatWithMode(fe.Mode)
+}
+
+// Unwrap 返回被包装的验证错误集合，便于使用 errors.As 取出
+func (fe *FormattedErrors) Unwrap() error {
+	return fe.Errors
+}
+
 // New 创建一个新的错误
 func New(text string) error {
 	return fmt.Errorf(text)
@@ -1,6 +1,7 @@
 package errors
 
 import (
+	goerrors "errors"
 	"strings"
 	"testing"
 
@@ -157,6 +158,26 @@ func TestValidationErrorMap_Error(t *testing.T) {
 	}
 }
 
+func TestValidationError_SchemaValueJSON(t *testing.T) {
+	errs := ValidationErrors{
+		{Path: "role", Message: "value must be one of: a, b", Tag: "enum", SchemaValue: []string{"a", "b"}},
+		{Path: "name", Message: "required", Tag: "required"},
+	}
+	assert.Equal(t,
+		`[{"path":"role","message":"value must be one of: a, b","tag":"enum","schema":["a","b"]},{"path":"name","message":"required","tag":"required"}]`,
+		errs.FormatWithMode(FormattingModeJSON))
+}
+
+func TestFormattedErrors(t *testing.T) {
+	errs := ValidationErrors{{Path: "name", Message: "required"}, {Path: "age", Message: "too small"}}
+	var err error = &FormattedErrors{Errors: errs, Mode: FormattingModeSimple}
+	assert.Equal(t, "required; too small", err.Error())
+
+	var ve ValidationErrors
+	assert.True(t, goerrors.As(err, &ve))
+	assert.Equal(t, errs, ve)
+}
+
 func TestNew(t *testing.T) {
 	err := New("test error")
 	assert.Error(t, err)
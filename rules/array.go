@@ -3,6 +3,8 @@ package rules
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/songzhibin97/jsonschema-validator/errors"
 )
@@ -10,9 +12,12 @@ import (
 // 注册数组相关规则
 func registerArrayRules(registry ValidatorRegistry) {
 	registry.RegisterValidator("items", validateItems)
+	registry.RegisterValidator("prefixItems", validatePrefixItems)
+	registry.RegisterValidator("additionalItems", validateAdditionalItems)
 	registry.RegisterValidator("minItems", validateMinItems)
 	registry.RegisterValidator("maxItems", validateMaxItems)
 	registry.RegisterValidator("uniqueItems", validateUniqueItems)
+	registry.RegisterValidator("uniqueBy", validateUniqueBy)
 }
 
 // validateItems 验证数组的元素
@@ -38,91 +43,39 @@ func validateItems(ctx context.Context, value interface{}, schemaValue interface
 		}
 	}
 
-	// 处理两种items模式：对象模式和数组模式
+	// 处理三种items模式：对象模式、数组（元组）模式和布尔模式
 	switch schema := schemaValue.(type) {
 	case map[string]interface{}:
-		// 对象模式：所有元素都使用同一个schema验证
-		for i, item := range arr {
-			itemPath := fmt.Sprintf("%s[%d]", path, i)
-
-			// 遍历schema中的验证关键字
-			for keyword, keywordValue := range schema {
-				// 跳过非验证关键字
-				if keyword == "title" || keyword == "description" || keyword == "default" || keyword == "examples" {
-					continue
-				}
-
-				validator := registry.GetValidator(keyword)
-				if validator == nil {
-					// 未知的关键字
-					continue
-				}
-
-				isValid, err := validator(ctx, item, keywordValue, itemPath)
-				if err != nil {
-					return false, err
-				}
-
-				if !isValid {
-					return false, &errors.ValidationError{
-						Path:    itemPath,
-						Message: fmt.Sprintf("array item validation failed for keyword '%s'", keyword),
-						Value:   item,
-						Tag:     keyword,
-					}
-				}
+		// 对象模式：prefixItems 之后的元素使用同一个schema验证
+		for i := prefixItemsCount(ctx); i < len(arr); i++ {
+			if err := validateArrayItem(ctx, registry, arr[i], schema, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return false, err
 			}
 		}
 
 	case []interface{}:
 		// 数组模式：每个元素都使用对应位置的schema验证
-		for i, itemSchema := range schema {
-			if i >= len(arr) {
-				// 数组元素数量不足
-				break
-			}
-
-			itemPath := fmt.Sprintf("%s[%d]", path, i)
-			item := arr[i]
-
-			itemSchemaObj, ok := itemSchema.(map[string]interface{})
-			if !ok {
-				continue
-			}
-
-			// 遍历schema中的验证关键字
-			for keyword, keywordValue := range itemSchemaObj {
-				// 跳过非验证关键字
-				if keyword == "title" || keyword == "description" || keyword == "default" || keyword == "examples" {
-					continue
-				}
-
-				validator := registry.GetValidator(keyword)
-				if validator == nil {
-					// 未知的关键字
-					continue
-				}
-
-				isValid, err := validator(ctx, item, keywordValue, itemPath)
-				if err != nil {
-					return false, err
-				}
+		if err := validatePositionalItems(ctx, registry, arr, schema, path); err != nil {
+			return false, err
+		}
 
-				if !isValid {
-					return false, &errors.ValidationError{
-						Path:    itemPath,
-						Message: fmt.Sprintf("array item validation failed for keyword '%s'", keyword),
-						Value:   item,
-						Tag:     keyword,
-					}
-				}
+	case bool:
+		// false 表示不允许 prefixItems 之外的元素
+		if limit := prefixItemsCount(ctx); !schema && len(arr) > limit {
+			return false, &errors.ValidationError{
+				Path:        path,
+				Message:     "array has more items than allowed",
+				Value:       value,
+				Tag:         "items",
+				Param:       fmt.Sprintf("%d", limit),
+				SchemaValue: schemaValue,
 			}
 		}
 
 	default:
 		return false, &errors.ValidationError{
 			Path:    path,
-			Message: "items must be an object or array",
+			Message: "items must be an object, array or boolean",
 			Value:   schemaValue,
 			Tag:     "items",
 		}
@@ -131,6 +84,138 @@ func validateItems(ctx context.Context, value interface{}, schemaValue interface
 	return true, nil
 }
 
+// validatePrefixItems 按位置验证数组开头的元素（draft 2020-12）
+func validatePrefixItems(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	arr, ok := value.([]interface{})
+	if !ok {
+		return false, &errors.ValidationError{Path: path, Message: "prefixItems can only be applied to arrays", Value: value, Tag: "prefixItems"}
+	}
+	schemas, ok := schemaValue.([]interface{})
+	if !ok {
+		return false, &errors.ValidationError{Path: path, Message: "prefixItems must be an array", Value: schemaValue, Tag: "prefixItems"}
+	}
+	registry, ok := ctx.Value("validator").(ValidatorRegistry)
+	if !ok {
+		return false, &errors.ValidationError{Path: path, Message: "validator not found in context", Tag: "prefixItems"}
+	}
+	if err := validatePositionalItems(ctx, registry, arr, schemas, path); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// validateAdditionalItems 验证超出元组形式 items 的数组元素；同级 items 不是元组时不做检查
+func validateAdditionalItems(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	arr, ok := value.([]interface{})
+	if !ok {
+		return false, &errors.ValidationError{Path: path, Message: "additionalItems can only be applied to arrays", Value: value, Tag: "additionalItems"}
+	}
+	tuple, ok := ctx.Value("items").([]interface{})
+	if !ok || len(arr) <= len(tuple) {
+		return true, nil
+	}
+
+	switch schema := schemaValue.(type) {
+	case bool:
+		if !schema {
+			return false, AdditionalItemsError(arr, len(tuple), path)
+		}
+	case map[string]interface{}:
+		registry, ok := ctx.Value("validator").(ValidatorRegistry)
+		if !ok {
+			return false, &errors.ValidationError{Path: path, Message: "validator not found in context", Tag: "additionalItems"}
+		}
+		for i := len(tuple); i < len(arr); i++ {
+			if err := validateArrayItem(ctx, registry, arr[i], schema, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return false, err
+			}
+		}
+	default:
+		return false, &errors.ValidationError{Path: path, Message: "additionalItems must be an object or boolean", Value: schemaValue, Tag: "additionalItems"}
+	}
+	return true, nil
+}
+
+// AdditionalItemsError 构造 additionalItems 为 false 时数组超出元组长度的错误，列出不允许的下标
+func AdditionalItemsError(arr []interface{}, allowed int, path string) *errors.ValidationError {
+	indices := make([]string, 0, len(arr)-allowed)
+	for i := allowed; i < len(arr); i++ {
+		indices = append(indices, strconv.Itoa(i))
+	}
+	noun := "index"
+	if len(indices) > 1 {
+		noun = "indices"
+	}
+	return &errors.ValidationError{
+		Path:        path,
+		Message:     fmt.Sprintf("additional items not allowed at %s %s", noun, strings.Join(indices, ", ")),
+		Value:       arr,
+		Tag:         "additionalItems",
+		Param:       strconv.Itoa(allowed),
+		SchemaValue: false,
+	}
+}
+
+// prefixItemsCount 返回同级 prefixItems 的长度，由验证器在上下文中提供
+func prefixItemsCount(ctx context.Context) int {
+	prefix, _ := ctx.Value("prefixItems").([]interface{})
+	return len(prefix)
+}
+
+// validatePositionalItems 使用对应位置的schema验证数组元素，多余的元素不在此处检查
+func validatePositionalItems(ctx context.Context, registry ValidatorRegistry, arr []interface{}, schemas []interface{}, path string) error {
+	for i, itemSchema := range schemas {
+		if i >= len(arr) {
+			// 数组元素数量不足
+			break
+		}
+		itemSchemaObj, ok := itemSchema.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if err := validateArrayItem(ctx, registry, arr[i], itemSchemaObj, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateArrayItem 使用子schema中的各个验证关键字验证单个数组元素
+func validateArrayItem(ctx context.Context, registry ValidatorRegistry, item interface{}, schema map[string]interface{}, itemPath string) error {
+	// 子schema可能有自己的 prefixItems 和 items，避免继承上层的值
+	ctx = context.WithValue(ctx, "prefixItems", schema["prefixItems"])
+	ctx = context.WithValue(ctx, "items", schema["items"])
+
+	// 遍历schema中的验证关键字
+	for keyword, keywordValue := range schema {
+		// 跳过非验证关键字
+		if keyword == "title" || keyword == "description" || keyword == "default" || keyword == "examples" {
+			continue
+		}
+
+		validator := registry.GetValidator(keyword)
+		if validator == nil {
+			// 未知的关键字
+			continue
+		}
+
+		isValid, err := validator(ctx, item, keywordValue, itemPath)
+		if err != nil {
+			return err
+		}
+
+		if !isValid {
+			return &errors.ValidationError{
+				Path:    itemPath,
+				Message: fmt.Sprintf("array item validation failed for keyword '%s'", keyword),
+				Value:   item,
+				Tag:     keyword,
+			}
+		}
+	}
+	return nil
+}
+
 // validateMinItems 验证数组最小长度
 func validateMinItems(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
 	arr, ok := value.([]interface{})
@@ -142,7 +227,7 @@ func validateMinItems(ctx context.Context, value interface{}, schemaValue interf
 		return false, &errors.ValidationError{Path: path, Message: "minItems must be a non-negative integer", Tag: "minItems"}
 	}
 	if len(arr) < min {
-		return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("fewer items than minimum %d", min), Tag: "minItems", Param: fmt.Sprintf("%d", min)}
+		return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("fewer items than minimum %d", min), Tag: "minItems", Param: fmt.Sprintf("%d", min), SchemaValue: schemaValue}
 	}
 	return true, nil
 }
@@ -158,7 +243,7 @@ func validateMaxItems(ctx context.Context, value interface{}, schemaValue interf
 		return false, &errors.ValidationError{Path: path, Message: "maxItems must be a non-negative integer", Tag: "maxItems"}
 	}
 	if len(arr) > max {
-		return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("more items than maximum %d", max), Tag: "maxItems", Param: fmt.Sprintf("%d", max)}
+		return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("more items than maximum %d", max), Tag: "maxItems", Param: fmt.Sprintf("%d", max), SchemaValue: schemaValue}
 	}
 	return true, nil
 }
@@ -176,12 +261,123 @@ func validateUniqueItems(ctx context.Context, value interface{}, schemaValue int
 	if !ok {
 		return false, &errors.ValidationError{Path: path, Message: "must be an array", Tag: "uniqueItems"}
 	}
-	seen := make(map[interface{}]struct{})
-	for _, item := range arr {
-		if _, exists := seen[item]; exists {
-			return false, &errors.ValidationError{Path: path, Message: "contains duplicate items", Tag: "uniqueItems"}
+	// 标量按归一化后的值哈希查找（1 与 1.0 视为相同），对象、数组和自定义数值逐个深度比较，报告第一对重复元素的下标
+	seen := make(map[interface{}]int)
+	var complexIndexes []int
+	for i, item := range arr {
+		first := -1
+		normalized, hashable := uniqueByKey(item, false)
+		if hashable && !isCustomNumber(ctx, item) {
+			if j, dup := seen[normalized]; dup {
+				first = j
+			} else {
+				seen[normalized] = i
+			}
+		} else {
+			for _, j := range complexIndexes {
+				if deepEqualJSONCtx(ctx, arr[j], item) {
+					first = j
+					break
+				}
+			}
+			if first < 0 {
+				complexIndexes = append(complexIndexes, i)
+			}
+		}
+		if first >= 0 {
+			return false, &errors.ValidationError{
+				Path:        fmt.Sprintf("%s[%d]", path, i),
+				Message:     fmt.Sprintf("contains duplicate items: items at indices %d and %d are duplicates", first, i),
+				Value:       item,
+				Tag:         "uniqueItems",
+				Param:       fmt.Sprintf("%d,%d", first, i),
+				SchemaValue: schemaValue,
+			}
 		}
-		seen[item] = struct{}{}
 	}
 	return true, nil
 }
+
+// validateUniqueBy 验证对象数组中指定属性的值互不相同，例如 {"property": "email", "caseInsensitive": true}；
+// caseInsensitive 为 true 时字符串按小写比较，缺少该属性的元素不参与比较，报告第一对重复元素的下标
+func validateUniqueBy(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	opts, ok := schemaValue.(map[string]interface{})
+	if !ok {
+		return false, &errors.ValidationError{Path: path, Message: "uniqueBy must be an object", Value: schemaValue, Tag: "uniqueBy"}
+	}
+	property, ok := opts["property"].(string)
+	if !ok || property == "" {
+		return false, &errors.ValidationError{Path: path, Message: "uniqueBy requires a property name", Value: schemaValue, Tag: "uniqueBy"}
+	}
+	caseInsensitive, _ := toBool(opts["caseInsensitive"])
+
+	arr, ok := value.([]interface{})
+	if !ok {
+		return false, &errors.ValidationError{Path: path, Message: "must be an array", Tag: "uniqueBy"}
+	}
+
+	// 标量键按归一化后的值哈希查找，对象和数组等复杂值逐个深度比较
+	seen := make(map[interface{}]int)
+	type indexedKey struct {
+		index int
+		key   interface{}
+	}
+	var complexKeys []indexedKey
+	for i, item := range arr {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		key, exists := obj[property]
+		if !exists {
+			continue
+		}
+		first := -1
+		if normalized, ok := uniqueByKey(key, caseInsensitive); ok {
+			if j, dup := seen[normalized]; dup {
+				first = j
+			} else {
+				seen[normalized] = i
+			}
+		} else {
+			for _, prev := range complexKeys {
+				if deepEqualJSON(prev.key, key) {
+					first = prev.index
+					break
+				}
+			}
+			if first < 0 {
+				complexKeys = append(complexKeys, indexedKey{index: i, key: key})
+			}
+		}
+		if first >= 0 {
+			return false, &errors.ValidationError{
+				Path:        fmt.Sprintf("%s[%d].%s", path, i, property),
+				Message:     fmt.Sprintf("items at index %d and %d have duplicate %s '%v'", first, i, property, key),
+				Value:       key,
+				Tag:         "uniqueBy",
+				Param:       property,
+				SchemaValue: schemaValue,
+			}
+		}
+	}
+	return true, nil
+}
+
+// uniqueByKey 返回用于哈希比较的归一化键：数值统一为 float64，字符串在 caseInsensitive 时转为小写；
+// 对象和数组返回 false
+func uniqueByKey(key interface{}, caseInsensitive bool) (interface{}, bool) {
+	if f, ok := toNumber(key); ok {
+		return f, true
+	}
+	switch k := key.(type) {
+	case string:
+		if caseInsensitive {
+			return strings.ToLower(k), true
+		}
+		return k, true
+	case bool, nil:
+		return k, true
+	}
+	return nil, false
+}
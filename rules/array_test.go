@@ -2,8 +2,10 @@ package rules
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
+	"github.com/songzhibin97/jsonschema-validator/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -41,6 +43,99 @@ func TestValidateItems(t *testing.T) {
 	}
 }
 
+func TestValidatePrefixItems(t *testing.T) {
+	registry := NewRegistry()
+	registerArrayRules(registry)
+	registerTypeRules(registry)
+	base := context.WithValue(context.Background(), "validator", registry)
+
+	prefix := []interface{}{
+		map[string]interface{}{"type": "string"},
+		map[string]interface{}{"type": "integer"},
+	}
+	ctx := context.WithValue(base, "prefixItems", prefix)
+
+	tests := []struct {
+		name        string
+		value       []interface{}
+		items       interface{}
+		expectValid bool
+		expectErr   string
+	}{
+		{"Valid tuple", []interface{}{"a", 1}, false, true, ""},
+		{"Short tuple", []interface{}{"a"}, false, true, ""},
+		{"Invalid position", []interface{}{1, 1}, false, false, "expected string"},
+		{"Extra item rejected", []interface{}{"a", 1, true}, false, false, "array has more items than allowed"},
+		{"Extra items checked against items schema", []interface{}{"a", 1, true}, map[string]interface{}{"type": "boolean"}, true, ""},
+		{"Extra items violate items schema", []interface{}{"a", 1, "x"}, map[string]interface{}{"type": "boolean"}, false, "expected boolean"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := validatePrefixItems(ctx, tt.value, prefix, "root")
+			if err == nil {
+				valid, err = validateItems(ctx, tt.value, tt.items, "root")
+			}
+			assert.Equal(t, tt.expectValid, valid)
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+			}
+		})
+	}
+
+	// 没有 prefixItems 时 items:false 拒绝任何元素
+	valid, err := validateItems(base, []interface{}{1}, false, "root")
+	assert.False(t, valid)
+	assert.Contains(t, err.Error(), "array has more items than allowed")
+}
+
+func TestValidateAdditionalItems(t *testing.T) {
+	registry := NewRegistry()
+	registerArrayRules(registry)
+	registerTypeRules(registry)
+	base := context.WithValue(context.Background(), "validator", registry)
+
+	tuple := []interface{}{
+		map[string]interface{}{"type": "string"},
+		map[string]interface{}{"type": "integer"},
+	}
+	ctx := context.WithValue(base, "items", tuple)
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		value       []interface{}
+		additional  interface{}
+		expectValid bool
+		expectErr   string
+	}{
+		{"Within tuple", ctx, []interface{}{"a", 1}, false, true, ""},
+		{"One extra item", ctx, []interface{}{"a", 1, true}, false, false, "additional items not allowed at index 2"},
+		{"Several extra items", ctx, []interface{}{"a", 1, true, nil}, false, false, "additional items not allowed at indices 2, 3"},
+		{"Extra items allowed", ctx, []interface{}{"a", 1, true}, true, true, ""},
+		{"Extra items match schema", ctx, []interface{}{"a", 1, true}, map[string]interface{}{"type": "boolean"}, true, ""},
+		{"Extra items violate schema", ctx, []interface{}{"a", 1, "x"}, map[string]interface{}{"type": "boolean"}, false, "expected boolean"},
+		{"Ignored without tuple items", base, []interface{}{"a", 1, true}, false, true, ""},
+		{"Invalid schema", ctx, []interface{}{"a", 1, true}, "no", false, "additionalItems must be an object or boolean"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := validateAdditionalItems(tt.ctx, tt.value, tt.additional, "root")
+			assert.Equal(t, tt.expectValid, valid)
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+			}
+		})
+	}
+}
+
 func TestValidateMinItems(t *testing.T) {
 	registry := NewRegistry()
 	registerArrayRules(registry)
@@ -123,6 +218,12 @@ func TestValidateUniqueItems(t *testing.T) {
 		{"Invalid duplicates", []interface{}{1, 1, 2}, true, "root", false, "contains duplicate items"},
 		{"No check", []interface{}{1, 1}, false, "root", true, ""},
 		{"Invalid type", "not an array", true, "root", false, "must be an array"},
+		{"Duplicate objects", []interface{}{map[string]interface{}{"a": 1.0}, map[string]interface{}{"a": 1.0}}, true, "root", false, "contains duplicate items"},
+		{"Unique arrays", []interface{}{[]interface{}{1.0, 2.0}, []interface{}{1.0, 3.0}}, true, "root", true, ""},
+		{"Duplicate arrays", []interface{}{[]interface{}{1.0}, []interface{}{1.0}}, true, "root", false, "contains duplicate items"},
+		{"Mixed numeric types", []interface{}{1, 1.0}, true, "root", false, "contains duplicate items"},
+		{"String and number differ", []interface{}{"1", 1.0, true, nil, false}, true, "root", true, ""},
+		{"Scalars and objects", []interface{}{1.0, map[string]interface{}{"a": 1.0}, "x", []interface{}{1.0}}, true, "root", true, ""},
 	}
 
 	for _, tt := range tests {
@@ -138,3 +239,89 @@ func TestValidateUniqueItems(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateUniqueItemsIndices(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      []interface{}
+		expectPath string
+		expectMsg  string
+		expectArgs string
+	}{
+		{"Primitive duplicates", []interface{}{"a", "b", "c", "b"}, "root[3]", "items at indices 1 and 3 are duplicates", "1,3"},
+		{"First pair reported", []interface{}{1.0, 2.0, 1.0, 2.0}, "root[2]", "items at indices 0 and 2 are duplicates", "0,2"},
+		{"Scalar after objects", []interface{}{map[string]interface{}{}, "a", []interface{}{}, "a"}, "root[3]", "items at indices 1 and 3 are duplicates", "1,3"},
+		{"Object duplicates", []interface{}{
+			map[string]interface{}{"id": 1.0, "tags": []interface{}{"x"}},
+			map[string]interface{}{"id": 2.0},
+			map[string]interface{}{"tags": []interface{}{"x"}, "id": 1.0},
+		}, "root[2]", "items at indices 0 and 2 are duplicates", "0,2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := validateUniqueItems(context.Background(), tt.value, true, "root")
+			assert.False(t, valid)
+			var ve *errors.ValidationError
+			if assert.ErrorAs(t, err, &ve) {
+				assert.Equal(t, tt.expectPath, ve.Path)
+				assert.Contains(t, ve.Message, tt.expectMsg)
+				assert.Equal(t, tt.expectArgs, ve.Param)
+				assert.Equal(t, "uniqueItems", ve.Tag)
+			}
+		})
+	}
+}
+
+func TestValidateUniqueBy(t *testing.T) {
+	ctx := context.Background()
+	byEmail := map[string]interface{}{"property": "email", "caseInsensitive": true}
+	user := func(email interface{}) map[string]interface{} { return map[string]interface{}{"email": email} }
+
+	tests := []struct {
+		name        string
+		value       interface{}
+		schemaValue interface{}
+		expectValid bool
+		expectErr   string
+		expectPath  string
+	}{
+		{"Distinct emails", []interface{}{user("a@x.com"), user("b@x.com")}, byEmail, true, "", ""},
+		{"Case-differing duplicates", []interface{}{user("Ann@x.com"), user("bob@x.com"), user("ann@X.com")}, byEmail, false, "items at index 0 and 2 have duplicate email", "root[2].email"},
+		{"Case-sensitive", []interface{}{user("Ann@x.com"), user("ann@x.com")}, map[string]interface{}{"property": "email"}, true, "", ""},
+		{"Numeric keys", []interface{}{user(float64(1)), user(1)}, byEmail, false, "items at index 0 and 1", "root[1].email"},
+		{"Object keys", []interface{}{user(map[string]interface{}{"a": 1}), user(map[string]interface{}{"a": float64(1)})}, byEmail, false, "items at index 0 and 1", "root[1].email"},
+		{"Missing property skipped", []interface{}{map[string]interface{}{}, map[string]interface{}{}, "x"}, byEmail, true, "", ""},
+		{"Non-array value", "x", byEmail, false, "must be an array", ""},
+		{"Missing property name", []interface{}{}, map[string]interface{}{}, false, "uniqueBy requires a property name", ""},
+		{"Invalid schema value", []interface{}{}, "email", false, "uniqueBy must be an object", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := validateUniqueBy(ctx, tt.value, tt.schemaValue, "root")
+			assert.Equal(t, tt.expectValid, valid)
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.expectErr)
+				if tt.expectPath != "" {
+					assert.Equal(t, tt.expectPath, err.(*errors.ValidationError).Path)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkValidateUniqueItems(b *testing.B) {
+	arr := make([]interface{}, 10000)
+	for i := range arr {
+		arr[i] = fmt.Sprintf("item-%d", i)
+	}
+	ctx := context.Background()
+	for i := 0; i < b.N; i++ {
+		validateUniqueItems(ctx, arr, true, "root")
+	}
+}
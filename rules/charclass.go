@@ -0,0 +1,105 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"unicode"
+
+	"github.com/songzhibin97/jsonschema-validator/errors"
+)
+
+// 注册字符类别相关规则
+func registerCharClassRules(registry ValidatorRegistry) {
+	registry.RegisterValidator("alpha", charClassRule("alpha", "letters", isAlphaString))
+	registry.RegisterValidator("alphanum", charClassRule("alphanum", "letters and digits", isAlphanumString))
+	registry.RegisterValidator("ascii", charClassRule("ascii", "ASCII characters", isASCIIString))
+	registry.RegisterValidator("numeric", validateNumeric)
+}
+
+// numericPattern 匹配十进制数字字符串，可带符号、小数和指数
+var numericPattern = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)([eE][+-]?\d+)?$`)
+
+// charClassRule 创建检查字符串中每个字符都属于指定类别的规则，schema 值为 false 时不做检查
+func charClassRule(tag string, class string, match func(string) bool) RuleFunc {
+	return func(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+		str, ok, err := charClassInput(tag, value, schemaValue, path)
+		if !ok {
+			return err == nil, err
+		}
+		if !match(str) {
+			return false, &errors.ValidationError{
+				Path:        path,
+				Message:     fmt.Sprintf("value must contain only %s", class),
+				Value:       value,
+				Tag:         tag,
+				SchemaValue: schemaValue,
+			}
+		}
+		return true, nil
+	}
+}
+
+// validateNumeric 验证字符串可以解析为十进制数字
+func validateNumeric(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	str, ok, err := charClassInput("numeric", value, schemaValue, path)
+	if !ok {
+		return err == nil, err
+	}
+	if !numericPattern.MatchString(str) {
+		return false, &errors.ValidationError{
+			Path:        path,
+			Message:     "value must be a numeric string",
+			Value:       value,
+			Tag:         "numeric",
+			SchemaValue: schemaValue,
+		}
+	}
+	return true, nil
+}
+
+// charClassInput 检查schema开关和值类型；ok 为 false 时若 err 为空表示无需检查
+func charClassInput(tag string, value interface{}, schemaValue interface{}, path string) (string, bool, error) {
+	enabled, ok := toBool(schemaValue)
+	if !ok {
+		return "", false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("%s must be a boolean", tag), Tag: tag}
+	}
+	if !enabled {
+		return "", false, nil
+	}
+	str, ok := value.(string)
+	if !ok {
+		return "", false, &errors.ValidationError{Path: path, Message: "must be a string", Value: value, Tag: tag}
+	}
+	return str, true, nil
+}
+
+// isAlphaString 检查字符串是否只包含字母
+func isAlphaString(s string) bool {
+	for _, r := range s {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// isAlphanumString 检查字符串是否只包含字母和数字
+func isAlphanumString(s string) bool {
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// isASCIIString 检查字符串是否只包含 ASCII 字符
+func isASCIIString(s string) bool {
+	for _, r := range s {
+		if r > unicode.MaxASCII {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,49 @@
+package rules
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCharClassRules(t *testing.T) {
+	registry := NewRegistry()
+	registerCharClassRules(registry)
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		rule        string
+		value       interface{}
+		schemaValue interface{}
+		expectValid bool
+		expectErr   string
+	}{
+		{"Alpha valid", "alpha", "Hello", true, true, ""},
+		{"Alpha invalid", "alpha", "Hello1", true, false, "value must contain only letters"},
+		{"Alphanum valid", "alphanum", "abc123", true, true, ""},
+		{"Alphanum invalid", "alphanum", "abc-123", true, false, "value must contain only letters and digits"},
+		{"ASCII valid", "ascii", "plain text!", true, true, ""},
+		{"ASCII invalid", "ascii", "café", true, false, "value must contain only ASCII characters"},
+		{"Numeric valid", "numeric", "-12.5e3", true, true, ""},
+		{"Numeric invalid", "numeric", "12a", true, false, "value must be a numeric string"},
+		{"Numeric rejects NaN", "numeric", "NaN", true, false, "value must be a numeric string"},
+		{"Disabled", "alpha", "123", false, true, ""},
+		{"Non-string value", "alpha", 123, true, false, "must be a string"},
+		{"Invalid schema", "ascii", "abc", []interface{}{true}, false, "ascii must be a boolean"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := registry.Get(tt.rule)(ctx, tt.value, tt.schemaValue, "root")
+			assert.Equal(t, tt.expectValid, valid)
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+			}
+		})
+	}
+}
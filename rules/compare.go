@@ -0,0 +1,77 @@
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/songzhibin97/jsonschema-validator/comparators"
+	"github.com/songzhibin97/jsonschema-validator/errors"
+)
+
+// 注册比较器相关规则
+func registerCompareRules(registry ValidatorRegistry) {
+	registry.RegisterValidator("compare", validateCompare)
+}
+
+// validateCompare 使用验证器中注册的比较器将值与参考值比较，schema 形如 {"op": "gt", "value": 0}
+func validateCompare(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	spec, ok := schemaValue.(map[string]interface{})
+	if !ok {
+		return false, &errors.ValidationError{Path: path, Message: "compare must be an object with op and value", Tag: "compare"}
+	}
+	op, ok := spec["op"].(string)
+	if !ok || op == "" {
+		return false, &errors.ValidationError{Path: path, Message: "compare op must be a non-empty string", Tag: "compare"}
+	}
+	reference, ok := spec["value"]
+	if !ok {
+		return false, &errors.ValidationError{Path: path, Message: "compare value is required", Tag: "compare"}
+	}
+
+	registry, ok := ctx.Value("validator").(comparators.ComparatorRegistry)
+	if !ok {
+		return false, &errors.ValidationError{
+			Path:    path,
+			Message: "validator not found in context",
+			Tag:     "compare",
+		}
+	}
+	compare := registry.GetComparator(op)
+	if compare == nil {
+		return false, &errors.ValidationError{
+			Path:    path,
+			Message: fmt.Sprintf("unknown comparator: %s", op),
+			Tag:     "compare",
+			Param:   op,
+		}
+	}
+
+	// 自定义数值类型（WithNumberParser）与按同一解析函数转换后的参考值比较
+	if isCustomNumber(ctx, value) {
+		if parsed, ok := parseCustomNumber(ctx, reference); ok {
+			reference = parsed
+		}
+	}
+	if !compare(normalizeJSONNumber(value), normalizeJSONNumber(reference)) {
+		return false, &errors.ValidationError{
+			Path:        path,
+			Message:     fmt.Sprintf("value must satisfy %s %v", op, reference),
+			Value:       value,
+			Tag:         "compare",
+			Param:       fmt.Sprintf("%s %v", op, reference),
+			SchemaValue: schemaValue,
+		}
+	}
+	return true, nil
+}
+
+// normalizeJSONNumber 将 json.Number 转换为 float64，以便比较器按数值比较
+func normalizeJSONNumber(value interface{}) interface{} {
+	if n, ok := value.(json.Number); ok {
+		if f, err := n.Float64(); err == nil {
+			return f
+		}
+	}
+	return value
+}
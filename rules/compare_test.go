@@ -0,0 +1,45 @@
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/songzhibin97/jsonschema-validator/comparators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCompare(t *testing.T) {
+	registry := comparators.NewSimpleComparatorRegistry()
+	assert.NoError(t, comparators.RegisterBuiltInComparators(registry))
+	ctx := context.WithValue(context.Background(), "validator", registry)
+
+	tests := []struct {
+		name        string
+		value       interface{}
+		schemaValue interface{}
+		expectValid bool
+		expectErr   string
+	}{
+		{"gt satisfied", 5.0, map[string]interface{}{"op": "gt", "value": 0.0}, true, ""},
+		{"gt violated", 0.0, map[string]interface{}{"op": "gt", "value": 0.0}, false, "value must satisfy gt 0"},
+		{"lt satisfied", json.Number("3"), map[string]interface{}{"op": "lt", "value": json.Number("10")}, true, ""},
+		{"lt violated", 12, map[string]interface{}{"op": "lt", "value": 10.0}, false, "value must satisfy lt 10"},
+		{"Unknown comparator", 1.0, map[string]interface{}{"op": "between", "value": 0.0}, false, "unknown comparator: between"},
+		{"Missing value", 1.0, map[string]interface{}{"op": "gt"}, false, "compare value is required"},
+		{"Invalid schema", 1.0, "gt", false, "compare must be an object"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := validateCompare(ctx, tt.value, tt.schemaValue, "root")
+			assert.Equal(t, tt.expectValid, valid)
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/songzhibin97/jsonschema-validator/errors"
+)
+
+// 注册常量相关规则
+func registerConstRules(registry ValidatorRegistry) {
+	registry.RegisterValidator("const", validateConst)
+}
+
+// validateConst 验证值与常量完全相等（对象和数组按深度比较，数值忽略类型差异）
+func validateConst(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	if !deepEqualJSONCtx(ctx, value, schemaValue) {
+		return false, &errors.ValidationError{
+			Path:    path,
+			Message: fmt.Sprintf("value must be equal to const %v", schemaValue),
+			Value:   value,
+			Tag:     "const",
+			Param:   fmt.Sprintf("%v", schemaValue),
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,77 @@
+package rules
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateConst(t *testing.T) {
+	registry := NewRegistry()
+	registerConstRules(registry)
+	ctx := context.WithValue(context.Background(), "validator", registry)
+
+	tests := []struct {
+		name        string
+		value       interface{}
+		schemaValue interface{}
+		expectValid bool
+		expectErr   string
+	}{
+		{"Equal string", "a", "a", true, ""},
+		{"Different string", "a", "b", false, "value must be equal to const"},
+		{"Int matches float", 1, 1.0, true, ""},
+		{"Number does not match string", 1.0, "1", false, "value must be equal to const"},
+		{"Null const", nil, nil, true, ""},
+		{
+			"Object const",
+			map[string]interface{}{"a": 1.0, "b": "x"},
+			map[string]interface{}{"a": 1, "b": "x"},
+			true, "",
+		},
+		{
+			"Array const",
+			[]interface{}{1.0, "two", true},
+			[]interface{}{1, "two", true},
+			true, "",
+		},
+		{
+			"Nested const",
+			map[string]interface{}{"a": map[string]interface{}{"b": []interface{}{1.0, map[string]interface{}{"c": nil}}}},
+			map[string]interface{}{"a": map[string]interface{}{"b": []interface{}{1, map[string]interface{}{"c": nil}}}},
+			true, "",
+		},
+		{
+			"Near miss in one field",
+			map[string]interface{}{"a": map[string]interface{}{"b": 1.0, "c": 2.0}},
+			map[string]interface{}{"a": map[string]interface{}{"b": 1.0, "c": 3.0}},
+			false, "value must be equal to const",
+		},
+		{
+			"Extra field",
+			map[string]interface{}{"a": 1.0, "b": 2.0},
+			map[string]interface{}{"a": 1.0},
+			false, "value must be equal to const",
+		},
+		{
+			"Array order matters",
+			[]interface{}{1.0, 2.0},
+			[]interface{}{2.0, 1.0},
+			false, "value must be equal to const",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := validateConst(ctx, tt.value, tt.schemaValue, "root")
+			assert.Equal(t, tt.expectValid, valid)
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package rules
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/songzhibin97/jsonschema-validator/errors"
+)
+
+// 注册内容编码相关规则
+func registerContentRules(registry ValidatorRegistry) {
+	registry.RegisterValidator("contentEncoding", validateContentEncoding)
+	registry.RegisterValidator("contentMediaType", validateContentMediaType)
+}
+
+// decodeContent 按编码方式解码字符串内容，encoding 为空时返回原始字节
+func decodeContent(str string, encoding string) ([]byte, error) {
+	switch strings.ToLower(encoding) {
+	case "":
+		return []byte(str), nil
+	case "base64":
+		return base64.StdEncoding.DecodeString(str)
+	default:
+		return nil, fmt.Errorf("unsupported contentEncoding: %s", encoding)
+	}
+}
+
+// validateContentEncoding 验证字符串是否符合指定的内容编码
+func validateContentEncoding(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	str, ok := value.(string)
+	if !ok {
+		return false, &errors.ValidationError{Path: path, Message: "must be a string", Tag: "contentEncoding"}
+	}
+	encoding, ok := schemaValue.(string)
+	if !ok {
+		return false, &errors.ValidationError{Path: path, Message: "contentEncoding must be a string", Tag: "contentEncoding"}
+	}
+	if strings.ToLower(encoding) != "base64" {
+		return false, &errors.ValidationError{
+			Path:    path,
+			Message: fmt.Sprintf("unsupported contentEncoding: %s", encoding),
+			Tag:     "contentEncoding",
+			Param:   encoding,
+		}
+	}
+	if _, err := decodeContent(str, encoding); err != nil {
+		return false, &errors.ValidationError{
+			Path:        path,
+			Message:     "value is not valid base64",
+			Value:       value,
+			Tag:         "contentEncoding",
+			Param:       encoding,
+			SchemaValue: schemaValue,
+		}
+	}
+	return true, nil
+}
+
+// validateContentMediaType 验证字符串（按同级 contentEncoding 解码后）是否符合指定的媒体类型
+func validateContentMediaType(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	str, ok := value.(string)
+	if !ok {
+		return false, &errors.ValidationError{Path: path, Message: "must be a string", Tag: "contentMediaType"}
+	}
+	mediaType, ok := schemaValue.(string)
+	if !ok {
+		return false, &errors.ValidationError{Path: path, Message: "contentMediaType must be a string", Tag: "contentMediaType"}
+	}
+	if strings.ToLower(mediaType) != "application/json" {
+		return false, &errors.ValidationError{
+			Path:    path,
+			Message: fmt.Sprintf("unsupported contentMediaType: %s", mediaType),
+			Tag:     "contentMediaType",
+			Param:   mediaType,
+		}
+	}
+
+	encoding, _ := ctx.Value("contentEncoding").(string)
+	data, err := decodeContent(str, encoding)
+	if err != nil {
+		// 编码错误由 contentEncoding 规则报告
+		return true, nil
+	}
+	if !json.Valid(data) {
+		return false, &errors.ValidationError{
+			Path:        path,
+			Message:     "value is not valid JSON",
+			Value:       value,
+			Tag:         "contentMediaType",
+			Param:       mediaType,
+			SchemaValue: schemaValue,
+		}
+	}
+	return true, nil
+}
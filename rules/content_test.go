@@ -0,0 +1,76 @@
+package rules
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateContentEncoding(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		value       interface{}
+		schemaValue interface{}
+		expectValid bool
+		expectErr   string
+	}{
+		{"Valid base64", base64.StdEncoding.EncodeToString([]byte(`{"a":1}`)), "base64", true, ""},
+		{"Corrupt base64", "not*base64!", "base64", false, "value is not valid base64"},
+		{"Bad padding", "YWJj=", "base64", false, "value is not valid base64"},
+		{"Unsupported encoding", "abc", "base32", false, "unsupported contentEncoding: base32"},
+		{"Invalid type", 123, "base64", false, "must be a string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := validateContentEncoding(ctx, tt.value, tt.schemaValue, "root")
+			assert.Equal(t, tt.expectValid, valid)
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestValidateContentMediaType(t *testing.T) {
+	encoded := context.WithValue(context.Background(), "contentEncoding", "base64")
+	plain := context.Background()
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		value       interface{}
+		expectValid bool
+		expectErr   string
+	}{
+		{"Encoded valid JSON", encoded, base64.StdEncoding.EncodeToString([]byte(`{"a":1}`)), true, ""},
+		{"Encoded invalid JSON", encoded, base64.StdEncoding.EncodeToString([]byte(`{"a":`)), false, "value is not valid JSON"},
+		{"Encoded corrupt base64 left to contentEncoding", encoded, "not*base64!", true, ""},
+		{"Plain valid JSON", plain, `[1, 2]`, true, ""},
+		{"Plain invalid JSON", plain, `{oops}`, false, "value is not valid JSON"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := validateContentMediaType(tt.ctx, tt.value, "application/json", "root")
+			assert.Equal(t, tt.expectValid, valid)
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+			}
+		})
+	}
+
+	valid, err := validateContentMediaType(plain, "x", "text/html", "root")
+	assert.False(t, valid)
+	assert.Contains(t, err.Error(), "unsupported contentMediaType: text/html")
+}
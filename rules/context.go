@@ -0,0 +1,43 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/songzhibin97/jsonschema-validator/errors"
+)
+
+// 注册上下文相关规则
+func registerContextRules(registry ValidatorRegistry) {
+	registry.RegisterValidator("requireContext", validateRequireContext)
+}
+
+// validateRequireContext 验证上下文中存在指定的键，schemaValue 为键名或键名数组；
+// 依赖上下文值的自定义规则可以与之组合，在调用方遗漏上下文时给出明确的错误而不是静默跳过
+func validateRequireContext(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	keys, ok := toStringSlice(schemaValue)
+	if str, isString := schemaValue.(string); isString {
+		keys, ok = []string{str}, true
+	}
+	if !ok {
+		return false, &errors.ValidationError{
+			Path:    path,
+			Message: "requireContext must be a string or an array of strings",
+			Value:   schemaValue,
+			Tag:     "requireContext",
+		}
+	}
+
+	for _, key := range keys {
+		if ctx.Value(key) == nil {
+			return false, &errors.ValidationError{
+				Path:        path,
+				Message:     fmt.Sprintf("required context value '%s' is missing", key),
+				Tag:         "requireContext",
+				Param:       key,
+				SchemaValue: schemaValue,
+			}
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,40 @@
+package rules
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateRequireContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), "tenantTier", "pro")
+	ctx = context.WithValue(ctx, "region", "eu")
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		schemaValue interface{}
+		expectValid bool
+		expectErr   string
+	}{
+		{"Single key present", ctx, "tenantTier", true, ""},
+		{"All keys present", ctx, []interface{}{"tenantTier", "region"}, true, ""},
+		{"Key missing", ctx, []interface{}{"tenantTier", "userID"}, false, "required context value 'userID' is missing"},
+		{"Empty context", context.Background(), "tenantTier", false, "required context value 'tenantTier' is missing"},
+		{"Invalid schema", ctx, 1, false, "requireContext must be a string or an array of strings"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := validateRequireContext(tt.ctx, 42, tt.schemaValue, "root")
+			assert.Equal(t, tt.expectValid, valid)
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+			}
+		})
+	}
+}
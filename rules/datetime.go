@@ -0,0 +1,99 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/songzhibin97/jsonschema-validator/errors"
+)
+
+// 注册时间相关规则
+func registerDateTimeRules(registry ValidatorRegistry) {
+	registry.RegisterValidator("pastDateTime", relativeTimeRule("pastDateTime", true))
+	registry.RegisterValidator("futureDateTime", relativeTimeRule("futureDateTime", false))
+	registry.RegisterValidator("dateFormat", validateDateFormat)
+}
+
+// validateDateFormat 验证字符串能按 Go 参考时间布局（例如 "2006/01/02"）解析
+func validateDateFormat(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	str, ok := value.(string)
+	if !ok {
+		return false, &errors.ValidationError{Path: path, Message: "must be a string", Value: value, Tag: "dateFormat"}
+	}
+	layout, ok := schemaValue.(string)
+	if !ok || layout == "" {
+		return false, &errors.ValidationError{Path: path, Message: "dateFormat must be a non-empty string", Tag: "dateFormat"}
+	}
+	if _, err := time.Parse(layout, str); err != nil {
+		return false, &errors.ValidationError{
+			Path:        path,
+			Message:     fmt.Sprintf("value does not match date format '%s'", layout),
+			Value:       value,
+			Tag:         "dateFormat",
+			Param:       layout,
+			SchemaValue: schemaValue,
+		}
+	}
+	return true, nil
+}
+
+// relativeTimeRule 创建检查 RFC3339 时间早于（past 为 true）或晚于当前时间的规则，schema 值为 false 时不做检查
+// 当前时间优先取上下文中的 clock，便于测试使用固定时钟
+func relativeTimeRule(tag string, past bool) RuleFunc {
+	return func(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+		enabled, ok := toBool(schemaValue)
+		if !ok {
+			return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("%s must be a boolean", tag), Tag: tag}
+		}
+		if !enabled {
+			return true, nil
+		}
+
+		var t time.Time
+		switch v := value.(type) {
+		case time.Time:
+			t = v
+		case string:
+			parsed, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return false, &errors.ValidationError{
+					Path:    path,
+					Message: "value must be an RFC3339 date-time",
+					Value:   value,
+					Tag:     tag,
+				}
+			}
+			t = parsed
+		default:
+			return false, &errors.ValidationError{Path: path, Message: "must be a string", Value: value, Tag: tag}
+		}
+
+		now := currentTime(ctx)
+		if past && !t.Before(now) {
+			return false, &errors.ValidationError{
+				Path:    path,
+				Message: "date-time must be in the past",
+				Value:   value,
+				Tag:     tag,
+			}
+		}
+		if !past && !t.After(now) {
+			return false, &errors.ValidationError{
+				Path:    path,
+				Message: "date-time must be in the future",
+				Value:   value,
+				Tag:     tag,
+			}
+		}
+		return true, nil
+	}
+}
+
+// currentTime 返回上下文中 clock 给出的当前时间，未设置时使用 time.Now
+func currentTime(ctx context.Context) time.Time {
+	if clock, ok := ctx.Value("clock").(func() time.Time); ok && clock != nil {
+		return clock()
+	}
+	return time.Now()
+}
@@ -0,0 +1,78 @@
+package rules
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelativeTimeRules(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	ctx := context.WithValue(context.Background(), "clock", func() time.Time { return now })
+	past := relativeTimeRule("pastDateTime", true)
+	future := relativeTimeRule("futureDateTime", false)
+
+	tests := []struct {
+		name        string
+		rule        RuleFunc
+		value       interface{}
+		schemaValue interface{}
+		expectValid bool
+		expectErr   string
+	}{
+		{"Past timestamp", past, "2024-05-31T12:00:00Z", true, true, ""},
+		{"Future timestamp not past", past, "2024-06-02T00:00:00+08:00", true, false, "date-time must be in the past"},
+		{"Now is not past", past, "2024-06-01T12:00:00Z", true, false, "date-time must be in the past"},
+		{"Future timestamp", future, "2024-06-01T12:00:01Z", true, true, ""},
+		{"Past timestamp not future", future, "2024-01-01T00:00:00Z", true, false, "date-time must be in the future"},
+		{"time.Time value", future, now.Add(time.Hour), true, true, ""},
+		{"Disabled", past, "2030-01-01T00:00:00Z", false, true, ""},
+		{"Invalid timestamp", past, "2024-05-31", true, false, "value must be an RFC3339 date-time"},
+		{"Non-string value", future, 42, true, false, "must be a string"},
+		{"Invalid schema value", past, "2024-05-31T12:00:00Z", []interface{}{}, false, "pastDateTime must be a boolean"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := tt.rule(ctx, tt.value, tt.schemaValue, "root")
+			assert.Equal(t, tt.expectValid, valid)
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestValidateDateFormat(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       interface{}
+		schemaValue interface{}
+		expectValid bool
+		expectErr   string
+	}{
+		{"Matches layout", "2024/06/01", "2006/01/02", true, ""},
+		{"Matches layout with time", "01-Jun-2024 13:45", "02-Jan-2006 15:04", true, ""},
+		{"Wrong separator", "2024-06-01", "2006/01/02", false, "value does not match date format '2006/01/02'"},
+		{"Invalid date", "2024/02/30", "2006/01/02", false, "value does not match date format"},
+		{"Non-string value", 20240601, "2006/01/02", false, "must be a string"},
+		{"Empty layout", "2024/06/01", "", false, "dateFormat must be a non-empty string"},
+		{"Non-string layout", "2024/06/01", 1, false, "dateFormat must be a non-empty string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := validateDateFormat(context.Background(), tt.value, tt.schemaValue, "root")
+			assert.Equal(t, tt.expectValid, valid)
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.expectErr)
+			}
+		})
+	}
+}
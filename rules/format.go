@@ -3,6 +3,7 @@ package rules
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/songzhibin97/jsonschema-validator/errors"
 )
@@ -10,19 +11,23 @@ import (
 // 注册格式验证相关规则
 func registerFormatRules(registry ValidatorRegistry) {
 	registry.RegisterValidator("format", validateFormat)
+	registry.RegisterValidator("formatAny", validateFormatAny)
 }
 
-// formatValidatorMap 保存所有支持的格式验证函数
-var formatValidatorMap = map[string]func(string) bool{
-	"email":     validateEmail,
-	"date-time": validateDateTime,
-	"date":      validateDate,
-	"time":      validateTime,
-	"uri":       validateURI,
-	"hostname":  validateHostname,
-	"ipv4":      validateIPv4,
-	"ipv6":      validateIPv6,
-	"uuid":      validateUUID,
+// builtinFormats 保存内置的格式验证函数
+var builtinFormats = map[string]func(string) bool{
+	"email":      validateEmail,
+	"date-time":  validateDateTime,
+	"date":       validateDate,
+	"time":       validateTime,
+	"uri":        validateURI,
+	"hostname":   validateHostname,
+	"ipv4":       validateIPv4,
+	"ipv6":       validateIPv6,
+	"uuid":       validateUUID,
+	"creditcard": validateCreditCard,
+	"semver":     validateSemver,
+	"bcp47":      validateBCP47,
 }
 
 // validateFormat 验证字符串格式
@@ -38,6 +43,14 @@ func validateFormat(ctx context.Context, value interface{}, schemaValue interfac
 		}
 	}
 
+	// 查找格式验证函数，注册表中的格式优先于内置的数值格式，允许用户覆盖 latitude/longitude
+	validator, exists := lookupFormat(ctx, format)
+	if !exists {
+		if check, ok := numericFormats[format]; ok {
+			return validateNumericFormat(ctx, value, format, check, path)
+		}
+	}
+
 	// 获取待验证的字符串
 	str, ok := value.(string)
 	if !ok {
@@ -49,12 +62,10 @@ func validateFormat(ctx context.Context, value interface{}, schemaValue interfac
 		}
 	}
 
-	// 查找格式验证函数
-	validator, exists := formatValidatorMap[format]
 	if !exists {
 		// 默认严格模式
 		mode, _ := ctx.Value("validationMode").(int)
-		if mode != 1 { // 非宽松模式，视为严格模式
+		if mode != 1 && formatAssertionEnabled(ctx, "unknownFormatAssertion") { // 非宽松模式，视为严格模式
 			return false, &errors.ValidationError{
 				Path:    path,
 				Message: fmt.Sprintf("unknown format: %s", format),
@@ -67,7 +78,7 @@ func validateFormat(ctx context.Context, value interface{}, schemaValue interfac
 	}
 
 	// 执行格式验证
-	if !validator(str) {
+	if formatAssertionEnabled(ctx, "formatAssertion") && !validator(str) {
 		return false, &errors.ValidationError{
 			Path:    path,
 			Message: fmt.Sprintf("invalid %s format", format),
@@ -80,9 +91,70 @@ func validateFormat(ctx context.Context, value interface{}, schemaValue interfac
 	return true, nil
 }
 
-// RegisterFormatValidator 注册自定义格式验证器
-func RegisterFormatValidator(name string, validator func(string) bool) {
-	if validator != nil {
-		formatValidatorMap[name] = validator
+// validateFormatAny 验证字符串至少满足列出的格式之一
+func validateFormatAny(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	formats, ok := toStringSlice(schemaValue)
+	if !ok || len(formats) == 0 {
+		return false, &errors.ValidationError{
+			Path:    path,
+			Message: "formatAny must be a non-empty array of strings",
+			Value:   schemaValue,
+			Tag:     "formatAny",
+		}
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return false, &errors.ValidationError{
+			Path:    path,
+			Message: "value must be a string",
+			Value:   value,
+			Tag:     "formatAny",
+		}
+	}
+
+	mode, _ := ctx.Value("validationMode").(int)
+	for _, format := range formats {
+		validator, exists := lookupFormat(ctx, format)
+		if !exists {
+			if mode != 1 && formatAssertionEnabled(ctx, "unknownFormatAssertion") { // 非宽松模式，视为严格模式
+				return false, &errors.ValidationError{
+					Path:    path,
+					Message: fmt.Sprintf("unknown format: %s", format),
+					Value:   value,
+					Tag:     "formatAny",
+					Param:   format,
+				}
+			}
+			continue
+		}
+		if !formatAssertionEnabled(ctx, "formatAssertion") || validator(str) {
+			return true, nil
+		}
+	}
+
+	return false, &errors.ValidationError{
+		Path:        path,
+		Message:     fmt.Sprintf("value does not match any of the formats: %s", strings.Join(formats, ", ")),
+		Value:       value,
+		Tag:         "formatAny",
+		Param:       strings.Join(formats, ","),
+		SchemaValue: schemaValue,
 	}
 }
+
+// formatAssertionEnabled 读取上下文中的格式断言开关，未设置时默认开启
+func formatAssertionEnabled(ctx context.Context, key string) bool {
+	enabled, ok := ctx.Value(key).(bool)
+	return !ok || enabled
+}
+
+// RegisterFormatValidator 注册全局自定义格式验证器，对所有验证器实例可见
+func RegisterFormatValidator(name string, validator func(string) bool) {
+	DefaultFormatRegistry.Register(name, validator)
+}
+
+// ListFormats 返回全局已注册格式名称的有序列表
+func ListFormats() []string {
+	return DefaultFormatRegistry.Names()
+}
@@ -0,0 +1,90 @@
+package rules
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// FormatRegistry 保存格式名称到验证函数的映射，可按验证器实例隔离自定义格式
+type FormatRegistry struct {
+	formats map[string]func(string) bool
+	parent  *FormatRegistry
+	mutex   sync.RWMutex
+}
+
+// DefaultFormatRegistry 是全局默认的格式注册表，包含内置格式和通过 RegisterFormatValidator 注册的格式
+var DefaultFormatRegistry = newDefaultFormatRegistry()
+
+// newDefaultFormatRegistry 创建包含内置格式的全局注册表
+func newDefaultFormatRegistry() *FormatRegistry {
+	formats := make(map[string]func(string) bool, len(builtinFormats))
+	for name, fn := range builtinFormats {
+		formats[name] = fn
+	}
+	return &FormatRegistry{formats: formats}
+}
+
+// NewFormatRegistry 创建一个新的格式注册表，未找到的格式会继续在 DefaultFormatRegistry 中查找
+func NewFormatRegistry() *FormatRegistry {
+	return &FormatRegistry{
+		formats: make(map[string]func(string) bool),
+		parent:  DefaultFormatRegistry,
+	}
+}
+
+// Register 注册格式验证函数，nil 函数会被忽略
+func (r *FormatRegistry) Register(name string, fn func(string) bool) {
+	if fn == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.formats[name] = fn
+}
+
+// Get 获取格式验证函数，本注册表未找到时在上级注册表中查找
+func (r *FormatRegistry) Get(name string) (func(string) bool, bool) {
+	r.mutex.RLock()
+	fn, ok := r.formats[name]
+	r.mutex.RUnlock()
+	if !ok && r.parent != nil {
+		return r.parent.Get(name)
+	}
+	return fn, ok
+}
+
+// Known 检查格式名称是否可用，包括本注册表及上级注册表中的格式和内置的数值格式
+func (r *FormatRegistry) Known(name string) bool {
+	if _, ok := numericFormats[name]; ok {
+		return true
+	}
+	_, ok := r.Get(name)
+	return ok
+}
+
+// Names 返回本注册表及上级注册表中所有格式名称的有序列表
+func (r *FormatRegistry) Names() []string {
+	seen := make(map[string]bool)
+	for reg := r; reg != nil; reg = reg.parent {
+		reg.mutex.RLock()
+		for name := range reg.formats {
+			seen[name] = true
+		}
+		reg.mutex.RUnlock()
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookupFormat 优先在上下文中验证器实例的格式注册表中查找，否则使用全局注册表
+func lookupFormat(ctx context.Context, name string) (func(string) bool, bool) {
+	if registry, ok := ctx.Value("formats").(*FormatRegistry); ok && registry != nil {
+		return registry.Get(name)
+	}
+	return DefaultFormatRegistry.Get(name)
+}
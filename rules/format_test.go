@@ -61,6 +61,132 @@ func TestValidateFormat(t *testing.T) {
 			expectValid: false,
 			expectErr:   "invalid uuid format",
 		},
+		{
+			name:        "Valid creditcard",
+			value:       "4012-8888-8888-1881",
+			schemaValue: "creditcard",
+			path:        "root",
+			ctx:         ctxStrict,
+			expectValid: true,
+			expectErr:   "",
+		},
+		{
+			name:        "Invalid creditcard",
+			value:       "4012-8888-8888-1882",
+			schemaValue: "creditcard",
+			path:        "root",
+			ctx:         ctxStrict,
+			expectValid: false,
+			expectErr:   "invalid creditcard format",
+		},
+		{
+			name:        "Valid semver",
+			value:       "2.0.0-rc.1+exp.sha.5114f85",
+			schemaValue: "semver",
+			path:        "root",
+			ctx:         ctxStrict,
+			expectValid: true,
+			expectErr:   "",
+		},
+		{
+			name:        "Invalid semver",
+			value:       "v1.2.3",
+			schemaValue: "semver",
+			path:        "root",
+			ctx:         ctxStrict,
+			expectValid: false,
+			expectErr:   "invalid semver format",
+		},
+		{
+			name:        "Valid bcp47 language",
+			value:       "en",
+			schemaValue: "bcp47",
+			path:        "root",
+			ctx:         ctxStrict,
+			expectValid: true,
+			expectErr:   "",
+		},
+		{
+			name:        "Valid bcp47 region",
+			value:       "en-US",
+			schemaValue: "bcp47",
+			path:        "root",
+			ctx:         ctxStrict,
+			expectValid: true,
+			expectErr:   "",
+		},
+		{
+			name:        "Valid bcp47 script and region",
+			value:       "zh-Hant-TW",
+			schemaValue: "bcp47",
+			path:        "root",
+			ctx:         ctxStrict,
+			expectValid: true,
+			expectErr:   "",
+		},
+		{
+			name:        "Valid bcp47 numeric region",
+			value:       "es-419",
+			schemaValue: "bcp47",
+			path:        "root",
+			ctx:         ctxStrict,
+			expectValid: true,
+			expectErr:   "",
+		},
+		{
+			name:        "Valid bcp47 variant",
+			value:       "de-CH-1996",
+			schemaValue: "bcp47",
+			path:        "root",
+			ctx:         ctxStrict,
+			expectValid: true,
+			expectErr:   "",
+		},
+		{
+			name:        "Valid bcp47 private use",
+			value:       "x-klingon",
+			schemaValue: "bcp47",
+			path:        "root",
+			ctx:         ctxStrict,
+			expectValid: true,
+			expectErr:   "",
+		},
+		{
+			name:        "Invalid bcp47 word",
+			value:       "english",
+			schemaValue: "bcp47",
+			path:        "root",
+			ctx:         ctxStrict,
+			expectValid: false,
+			expectErr:   "invalid bcp47 format",
+		},
+		{
+			name:        "Invalid bcp47 underscore",
+			value:       "en_US",
+			schemaValue: "bcp47",
+			path:        "root",
+			ctx:         ctxStrict,
+			expectValid: false,
+			expectErr:   "invalid bcp47 format",
+		},
+		{
+			name:        "Invalid bcp47 trailing hyphen",
+			value:       "en-",
+			schemaValue: "bcp47",
+			path:        "root",
+			ctx:         ctxStrict,
+			expectValid: false,
+			expectErr:   "invalid bcp47 format",
+		},
+		{
+			name:        "Invalid bcp47 long region",
+			value:       "en-USA1",
+			schemaValue: "bcp47",
+			path:        "root",
+			ctx:         ctxStrict,
+			expectValid: false,
+			expectErr:   "invalid bcp47 format",
+		},
 		{
 			name:        "Unknown format strict",
 			value:       "test",
@@ -115,16 +241,7 @@ func TestValidateFormat(t *testing.T) {
 	}
 }
 
-func TestRegisterFormatValidator(t *testing.T) {
-	// 备份原始验证器映射
-	originalMap := make(map[string]func(string) bool)
-	for k, v := range formatValidatorMap {
-		originalMap[k] = v
-	}
-	defer func() {
-		formatValidatorMap = originalMap
-	}()
-
+func TestFormatRegistry(t *testing.T) {
 	tests := []struct {
 		name        string
 		format      string
@@ -161,16 +278,11 @@ func TestRegisterFormatValidator(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// 清空映射以隔离测试
-			formatValidatorMap = make(map[string]func(string) bool)
-			registry := NewRegistry()
-			registry.RegisterValidator("format", validateFormat)
-			ctx := context.WithValue(context.Background(), "validator", registry)
-
-			// 注册验证器
-			RegisterFormatValidator(tt.format, tt.validator)
+			// 每个用例使用独立的格式注册表
+			formats := NewFormatRegistry()
+			formats.Register(tt.format, tt.validator)
+			ctx := context.WithValue(context.Background(), "formats", formats)
 
-			// 验证
 			valid, err := validateFormat(ctx, tt.input, tt.format, "root")
 			if tt.validator == nil && tt.format != "" {
 				// 如果注册了nil验证器，预期格式不存在
@@ -186,6 +298,118 @@ func TestRegisterFormatValidator(t *testing.T) {
 					assert.Contains(t, err.Error(), fmt.Sprintf("invalid %s format", tt.format), "error message mismatch for %s", tt.name)
 				}
 			}
+
+			// 实例级注册不影响全局注册表
+			_, exists := DefaultFormatRegistry.Get(tt.format)
+			assert.False(t, exists)
+		})
+	}
+}
+
+func TestFormatRegistryFallback(t *testing.T) {
+	formats := NewFormatRegistry()
+	formats.Register("email", func(s string) bool { return s == "override" })
+	ctx := context.WithValue(context.Background(), "formats", formats)
+
+	// 实例格式覆盖同名全局格式
+	valid, _ := validateFormat(ctx, "override", "email", "root")
+	assert.True(t, valid)
+
+	// 未注册的格式回退到全局注册表
+	valid, err := validateFormat(ctx, "10.0.0.1", "ipv4", "root")
+	assert.True(t, valid)
+	assert.NoError(t, err)
+
+	assert.Equal(t, DefaultFormatRegistry.Names(), formats.Names())
+}
+
+func TestRegisterFormatValidator(t *testing.T) {
+	RegisterFormatValidator("test-global-format", func(s string) bool { return s == "ok" })
+	// 全局注册表在测试间共享，测试结束后移除注册的格式
+	t.Cleanup(func() {
+		DefaultFormatRegistry.mutex.Lock()
+		delete(DefaultFormatRegistry.formats, "test-global-format")
+		DefaultFormatRegistry.mutex.Unlock()
+	})
+
+	valid, err := validateFormat(context.Background(), "ok", "test-global-format", "root")
+	assert.True(t, valid)
+	assert.NoError(t, err)
+
+	ctx := context.WithValue(context.Background(), "formats", NewFormatRegistry())
+	valid, err = validateFormat(ctx, "nope", "test-global-format", "root")
+	assert.False(t, valid)
+	assert.Error(t, err)
+	assert.Contains(t, ListFormats(), "test-global-format")
+}
+
+func TestValidateFormatAny(t *testing.T) {
+	registry := NewRegistry()
+	registerFormatRules(registry)
+	ctxStrict := context.WithValue(context.Background(), "validator", registry)
+	ctxLoose := context.WithValue(ctxStrict, "validationMode", 1)
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		value       interface{}
+		schemaValue interface{}
+		expectValid bool
+		expectErr   string
+	}{
+		{"Valid as email", ctxStrict, "user@example.com", []interface{}{"email", "ipv4"}, true, ""},
+		{"Valid as ipv4", ctxStrict, "10.0.0.1", []interface{}{"email", "ipv4"}, true, ""},
+		{"Valid as none", ctxStrict, "not-an-address", []interface{}{"email", "ipv4"}, false, "value does not match any of the formats: email, ipv4"},
+		{"Unknown format strict", ctxStrict, "x", []interface{}{"nope", "email"}, false, "unknown format: nope"},
+		{"Unknown format loose skipped", ctxLoose, "user@example.com", []interface{}{"nope", "email"}, true, ""},
+		{"Empty list", ctxStrict, "x", []interface{}{}, false, "formatAny must be a non-empty array of strings"},
+		{"Non-string value", ctxStrict, 1, []interface{}{"email"}, false, "value must be a string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := validateFormatAny(tt.ctx, tt.value, tt.schemaValue, "root")
+			assert.Equal(t, tt.expectValid, valid)
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestValidateFormatAssertionOptions(t *testing.T) {
+	base := context.Background()
+	noAssertion := context.WithValue(base, "formatAssertion", false)
+	noUnknownAssertion := context.WithValue(base, "unknownFormatAssertion", false)
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		value       string
+		format      string
+		expectValid bool
+		expectErr   string
+	}{
+		{"Known format asserted by default", base, "invalid", "email", false, "invalid email format"},
+		{"Known format not asserted", noAssertion, "invalid", "email", true, ""},
+		{"Unknown format asserted by default", base, "x", "nope", false, "unknown format: nope"},
+		{"Unknown format not asserted", noUnknownAssertion, "x", "nope", true, ""},
+		{"Known format still asserted without unknown assertion", noUnknownAssertion, "invalid", "email", false, "invalid email format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := validateFormat(tt.ctx, tt.value, tt.format, "root")
+			assert.Equal(t, tt.expectValid, valid)
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+			}
 		})
 	}
 }
@@ -0,0 +1,95 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/songzhibin97/jsonschema-validator/errors"
+)
+
+// 注册地理坐标相关规则
+func registerGeoRules(registry ValidatorRegistry) {
+	registry.RegisterValidator("geopoint", validateGeoPoint)
+}
+
+// numericFormats 保存作用于数值而非字符串的格式
+var numericFormats = map[string]func(float64) bool{
+	"latitude":  isLatitude,
+	"longitude": isLongitude,
+}
+
+// isLatitude 检查纬度是否在 -90 到 90 之间
+func isLatitude(f float64) bool {
+	return f >= -90 && f <= 90
+}
+
+// isLongitude 检查经度是否在 -180 到 180 之间
+func isLongitude(f float64) bool {
+	return f >= -180 && f <= 180
+}
+
+// validateNumericFormat 验证 latitude/longitude 等数值格式
+func validateNumericFormat(ctx context.Context, value interface{}, format string, check func(float64) bool, path string) (bool, error) {
+	f, ok := toNumber(value)
+	if !ok {
+		return false, &errors.ValidationError{
+			Path:    path,
+			Message: "value must be a number",
+			Value:   value,
+			Tag:     "format",
+			Param:   format,
+		}
+	}
+	if formatAssertionEnabled(ctx, "formatAssertion") && !check(f) {
+		return false, &errors.ValidationError{
+			Path:    path,
+			Message: fmt.Sprintf("invalid %s format", format),
+			Value:   value,
+			Tag:     "format",
+			Param:   format,
+		}
+	}
+	return true, nil
+}
+
+// validateGeoPoint 验证值为包含合法 lat 和 lng 数值的对象，schema 值为 false 时不做检查
+func validateGeoPoint(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	enabled, ok := toBool(schemaValue)
+	if !ok {
+		return false, &errors.ValidationError{Path: path, Message: "geopoint must be a boolean", Tag: "geopoint"}
+	}
+	if !enabled {
+		return true, nil
+	}
+
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return false, &errors.ValidationError{Path: path, Message: "geopoint must be an object with lat and lng", Value: value, Tag: "geopoint"}
+	}
+	for _, coord := range []struct {
+		key   string
+		check func(float64) bool
+		bound string
+	}{
+		{"lat", isLatitude, "-90 and 90"},
+		{"lng", isLongitude, "-180 and 180"},
+	} {
+		raw, exists := obj[coord.key]
+		if !exists {
+			return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("geopoint is missing %s", coord.key), Value: value, Tag: "geopoint"}
+		}
+		f, ok := toNumber(raw)
+		if !ok {
+			return false, &errors.ValidationError{Path: path + "." + coord.key, Message: fmt.Sprintf("%s must be a number", coord.key), Value: raw, Tag: "geopoint"}
+		}
+		if !coord.check(f) {
+			return false, &errors.ValidationError{
+				Path:    path + "." + coord.key,
+				Message: fmt.Sprintf("%s must be between %s", coord.key, coord.bound),
+				Value:   raw,
+				Tag:     "geopoint",
+			}
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,93 @@
+package rules
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoordinateFormats(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name        string
+		format      string
+		value       interface{}
+		expectValid bool
+		expectErr   string
+	}{
+		{"Latitude in range", "latitude", 45.5, true, ""},
+		{"Latitude bound", "latitude", -90, true, ""},
+		{"Latitude out of range", "latitude", 90.1, false, "invalid latitude format"},
+		{"Longitude in range", "longitude", -122.4, true, ""},
+		{"Longitude bound", "longitude", 180, true, ""},
+		{"Longitude out of range", "longitude", -181.0, false, "invalid longitude format"},
+		{"String value", "latitude", "45", false, "value must be a number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := validateFormat(ctx, tt.value, tt.format, "root")
+			assert.Equal(t, tt.expectValid, valid)
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestRegisteredFormatOverridesCoordinateFormat(t *testing.T) {
+	formats := NewFormatRegistry()
+	formats.Register("latitude", func(s string) bool { return strings.HasSuffix(s, "N") || strings.HasSuffix(s, "S") })
+	ctx := context.WithValue(context.Background(), "formats", formats)
+
+	valid, err := validateFormat(ctx, "45.5N", "latitude", "root")
+	assert.True(t, valid)
+	assert.NoError(t, err)
+
+	valid, err = validateFormat(ctx, "45.5", "latitude", "root")
+	assert.False(t, valid)
+	assert.ErrorContains(t, err, "invalid latitude format")
+
+	// 未覆盖的 longitude 仍使用内置的数值格式
+	valid, err = validateFormat(ctx, -122.4, "longitude", "root")
+	assert.True(t, valid)
+	assert.NoError(t, err)
+}
+
+func TestValidateGeoPoint(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name        string
+		value       interface{}
+		schemaValue interface{}
+		expectValid bool
+		expectErr   string
+	}{
+		{"Valid point", map[string]interface{}{"lat": 48.85, "lng": 2.35}, true, true, ""},
+		{"Latitude out of range", map[string]interface{}{"lat": 100.0, "lng": 2.35}, true, false, "lat must be between -90 and 90"},
+		{"Longitude out of range", map[string]interface{}{"lat": 10.0, "lng": 200.0}, true, false, "lng must be between -180 and 180"},
+		{"Missing lng", map[string]interface{}{"lat": 10.0}, true, false, "geopoint is missing lng"},
+		{"Non-numeric lat", map[string]interface{}{"lat": "north", "lng": 0.0}, true, false, "lat must be a number"},
+		{"Not an object", []interface{}{1.0, 2.0}, true, false, "geopoint must be an object with lat and lng"},
+		{"Disabled", "anything", false, true, ""},
+		{"Invalid schema", map[string]interface{}{}, []interface{}{true}, false, "geopoint must be a boolean"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := validateGeoPoint(ctx, tt.value, tt.schemaValue, "root")
+			assert.Equal(t, tt.expectValid, valid)
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/songzhibin97/jsonschema-validator/errors"
+)
+
+// 注册长度相关规则
+func registerLengthRules(registry ValidatorRegistry) {
+	registry.RegisterValidator("len", validateLen)
+}
+
+// validateLen 验证精确长度：字符串按码点计数，切片和数组按元素个数，映射按键个数
+func validateLen(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	expected, ok := toInt(schemaValue)
+	if !ok || expected < 0 {
+		return false, &errors.ValidationError{Path: path, Message: "len must be a non-negative integer", Tag: "len"}
+	}
+
+	var actual int
+	if str, ok := value.(string); ok {
+		actual = stringLength(ctx, str)
+	} else {
+		rv := reflect.ValueOf(value)
+		switch rv.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map:
+			actual = rv.Len()
+		default:
+			return false, &errors.ValidationError{
+				Path:    path,
+				Message: "len can only be applied to strings, arrays and objects",
+				Value:   value,
+				Tag:     "len",
+			}
+		}
+	}
+
+	if actual != expected {
+		return false, &errors.ValidationError{
+			Path:        path,
+			Message:     fmt.Sprintf("length must be exactly %d, got %d", expected, actual),
+			Value:       value,
+			Tag:         "len",
+			Param:       fmt.Sprintf("%d", expected),
+			SchemaValue: schemaValue,
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,44 @@
+package rules
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateLen(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		value       interface{}
+		schemaValue interface{}
+		expectValid bool
+		expectErr   string
+	}{
+		{"String exact", "FR", 2, true, ""},
+		{"String too long", "FRA", 2, false, "length must be exactly 2, got 3"},
+		{"Multibyte string", "日本", 2, true, ""},
+		{"JSON array", []interface{}{1, 2, 3}, 3, true, ""},
+		{"Go slice mismatch", []string{"a"}, 2, false, "length must be exactly 2, got 1"},
+		{"Go array", [2]int{1, 2}, 2, true, ""},
+		{"Object keys", map[string]interface{}{"a": 1, "b": 2}, 2, true, ""},
+		{"Map mismatch", map[string]int{"a": 1}, 0, false, "length must be exactly 0, got 1"},
+		{"Unsupported type", 42, 2, false, "len can only be applied to strings, arrays and objects"},
+		{"Invalid schema", "ab", "two", false, "len must be a non-negative integer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := validateLen(ctx, tt.value, tt.schemaValue, "root")
+			assert.Equal(t, tt.expectValid, valid)
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 // 注册逻辑组合相关规则
 func registerLogicalRules(registry ValidatorRegistry) {
 	registry.RegisterValidator("allOf", validateAllOf)
+	registry.RegisterValidator("extends", validateExtends)
 	registry.RegisterValidator("anyOf", validateAnyOf)
 	registry.RegisterValidator("oneOf", validateOneOf)
 	registry.RegisterValidator("not", validateNot)
@@ -17,14 +18,36 @@ func registerLogicalRules(registry ValidatorRegistry) {
 
 // validateAllOf 验证数据满足所有指定的schema
 func validateAllOf(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	return validateAllSchemas(ctx, value, schemaValue, path, "allOf")
+}
+
+// validateExtends 处理 draft-03 的 extends：值可以是单个schema或schema数组，按 allOf 语义验证
+func validateExtends(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	switch base := schemaValue.(type) {
+	case map[string]interface{}:
+		schemaValue = []interface{}{base}
+	case []interface{}:
+	default:
+		return false, &errors.ValidationError{
+			Path:    path,
+			Message: "extends must be an object or an array",
+			Value:   schemaValue,
+			Tag:     "extends",
+		}
+	}
+	return validateAllSchemas(ctx, value, schemaValue, path, "extends")
+}
+
+// validateAllSchemas 验证数据满足 schemaValue 中的所有schema，tag 为报告错误时使用的关键字
+func validateAllSchemas(ctx context.Context, value interface{}, schemaValue interface{}, path string, tag string) (bool, error) {
 	// 获取schema数组
 	schemas, ok := schemaValue.([]interface{})
 	if !ok {
 		return false, &errors.ValidationError{
 			Path:    path,
-			Message: "allOf must be an array",
+			Message: fmt.Sprintf("%s must be an array", tag),
 			Value:   schemaValue,
-			Tag:     "allOf",
+			Tag:     tag,
 		}
 	}
 
@@ -34,7 +57,7 @@ func validateAllOf(ctx context.Context, value interface{}, schemaValue interface
 		return false, &errors.ValidationError{
 			Path:    path,
 			Message: "validator not found in context",
-			Tag:     "allOf",
+			Tag:     tag,
 		}
 	}
 
@@ -42,9 +65,9 @@ func validateAllOf(ctx context.Context, value interface{}, schemaValue interface
 	if len(schemas) == 0 {
 		return false, &errors.ValidationError{
 			Path:    path,
-			Message: "allOf cannot be empty",
+			Message: fmt.Sprintf("%s cannot be empty", tag),
 			Value:   schemaValue,
-			Tag:     "allOf",
+			Tag:     tag,
 		}
 	}
 
@@ -53,14 +76,14 @@ func validateAllOf(ctx context.Context, value interface{}, schemaValue interface
 		schemaObj, ok := schema.(map[string]interface{})
 		if !ok {
 			return false, &errors.ValidationError{
-				Path:    fmt.Sprintf("%s.allOf[%d]", path, i),
+				Path:    fmt.Sprintf("%s.%s[%d]", path, tag, i),
 				Message: "schema must be an object",
 				Value:   schema,
-				Tag:     "allOf",
+				Tag:     tag,
 			}
 		}
 
-		schemaPath := fmt.Sprintf("%s.allOf[%d]", path, i)
+		schemaPath := fmt.Sprintf("%s.%s[%d]", path, tag, i)
 
 		// 遍历schema中的验证关键字
 		for keyword, keywordValue := range schemaObj {
@@ -77,18 +100,18 @@ func validateAllOf(ctx context.Context, value interface{}, schemaValue interface
 			if err != nil {
 				return false, &errors.ValidationError{
 					Path:    schemaPath,
-					Message: fmt.Sprintf("failed to validate against schema at allOf[%d] for keyword '%s': %v", i, keyword, err),
+					Message: fmt.Sprintf("failed to validate against schema at %s[%d] for keyword '%s': %v", tag, i, keyword, err),
 					Value:   value,
-					Tag:     "allOf",
+					Tag:     tag,
 				}
 			}
 
 			if !isValid {
 				return false, &errors.ValidationError{
 					Path:    schemaPath,
-					Message: fmt.Sprintf("failed to validate against schema at allOf[%d] for keyword '%s'", i, keyword),
+					Message: fmt.Sprintf("failed to validate against schema at %s[%d] for keyword '%s'", tag, i, keyword),
 					Value:   value,
-					Tag:     "allOf",
+					Tag:     tag,
 				}
 			}
 		}
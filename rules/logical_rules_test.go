@@ -60,6 +60,63 @@ func TestValidateAllOf(t *testing.T) {
 	}
 }
 
+func TestValidateExtends(t *testing.T) {
+	registry := NewRegistry()
+	registry.RegisterValidator("type", mockTypeValidator)
+	registry.RegisterValidator("required", requiredValidator)
+	ctx := context.WithValue(context.Background(), "validator", registry)
+
+	base := map[string]interface{}{"required": []interface{}{"id"}}
+
+	tests := []struct {
+		name        string
+		value       interface{}
+		schemaValue interface{}
+		expectValid bool
+		expectErr   string
+	}{
+		{
+			name:        "Inline base satisfied",
+			value:       map[string]interface{}{"id": 1},
+			schemaValue: base,
+			expectValid: true,
+		},
+		{
+			name:        "Inline base missing field",
+			value:       map[string]interface{}{"name": "x"},
+			schemaValue: base,
+			expectValid: false,
+			expectErr:   "failed to validate against schema at extends[0] for keyword 'required'",
+		},
+		{
+			name:        "Array of bases",
+			value:       map[string]interface{}{"id": 1},
+			schemaValue: []interface{}{base, map[string]interface{}{"type": "string"}},
+			expectValid: false,
+			expectErr:   "extends[1]",
+		},
+		{
+			name:        "Invalid extends value",
+			value:       map[string]interface{}{"id": 1},
+			schemaValue: "base",
+			expectValid: false,
+			expectErr:   "extends must be an object or an array",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := validateExtends(ctx, tt.value, tt.schemaValue, "root")
+			assert.Equal(t, tt.expectValid, valid)
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.expectErr)
+			}
+		})
+	}
+}
+
 func TestValidateNot(t *testing.T) {
 	registry := NewRegistry()
 	registry.RegisterValidator("type", mockTypeValidator)
@@ -2,9 +2,14 @@ package rules
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math"
+	"math/big"
+	"strconv"
+	"strings"
 
+	"github.com/songzhibin97/jsonschema-validator/comparators"
 	"github.com/songzhibin97/jsonschema-validator/errors"
 )
 
@@ -15,10 +20,36 @@ func registerNumberRules(registry ValidatorRegistry) {
 	registry.RegisterValidator("exclusiveMinimum", validateExclusiveMinimum)
 	registry.RegisterValidator("exclusiveMaximum", validateExclusiveMaximum)
 	registry.RegisterValidator("multipleOf", validateMultipleOf)
+	registry.RegisterValidator("maxDecimals", validateMaxDecimals)
+
+	// go-playground 风格的数值边界标签
+	registry.RegisterValidator("gt", aliasRule("gt", validateExclusiveMinimum))
+	registry.RegisterValidator("gte", aliasRule("gte", validateMinimum))
+	registry.RegisterValidator("lt", aliasRule("lt", validateExclusiveMaximum))
+	registry.RegisterValidator("lte", aliasRule("lte", validateMaximum))
+}
+
+// aliasRule 以别名复用已有规则，错误中的标签替换为别名
+func aliasRule(tag string, fn RuleFunc) RuleFunc {
+	return func(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+		valid, err := fn(ctx, value, schemaValue, path)
+		if ve, ok := err.(*errors.ValidationError); ok {
+			aliased := *ve
+			aliased.Tag = tag
+			return valid, &aliased
+		}
+		return valid, err
+	}
 }
 
 // validateMinimum 验证数值最小值
 func validateMinimum(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	if satisfied, handled := compareCustomNumber(ctx, value, schemaValue, "ge"); handled {
+		if !satisfied {
+			return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("less than minimum %v", schemaValue), Value: value, Tag: "minimum", Param: fmt.Sprintf("%v", schemaValue), SchemaValue: schemaValue}
+		}
+		return true, nil
+	}
 	v, ok := toFloat64(value)
 	if !ok {
 		return false, &errors.ValidationError{Path: path, Message: "must be a number", Tag: "minimum"}
@@ -28,13 +59,19 @@ func validateMinimum(ctx context.Context, value interface{}, schemaValue interfa
 		return false, &errors.ValidationError{Path: path, Message: "minimum must be a number", Tag: "minimum"}
 	}
 	if v < min {
-		return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("less than minimum %v", min), Tag: "minimum", Param: fmt.Sprintf("%v", min)}
+		return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("less than minimum %v", min), Tag: "minimum", Param: fmt.Sprintf("%v", min), SchemaValue: schemaValue}
 	}
 	return true, nil
 }
 
 // validateMaximum 验证数值最大值
 func validateMaximum(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	if satisfied, handled := compareCustomNumber(ctx, value, schemaValue, "le"); handled {
+		if !satisfied {
+			return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("greater than maximum %v", schemaValue), Value: value, Tag: "maximum", Param: fmt.Sprintf("%v", schemaValue), SchemaValue: schemaValue}
+		}
+		return true, nil
+	}
 	v, ok := toFloat64(value)
 	if !ok {
 		return false, &errors.ValidationError{Path: path, Message: "must be a number", Tag: "maximum"}
@@ -44,13 +81,19 @@ func validateMaximum(ctx context.Context, value interface{}, schemaValue interfa
 		return false, &errors.ValidationError{Path: path, Message: "maximum must be a number", Tag: "maximum"}
 	}
 	if v > max {
-		return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("greater than maximum %v", max), Tag: "maximum", Param: fmt.Sprintf("%v", max)}
+		return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("greater than maximum %v", max), Tag: "maximum", Param: fmt.Sprintf("%v", max), SchemaValue: schemaValue}
 	}
 	return true, nil
 }
 
 // validateExclusiveMinimum 验证数值严格大于最小值
 func validateExclusiveMinimum(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	if satisfied, handled := compareCustomNumber(ctx, value, schemaValue, "gt"); handled {
+		if !satisfied {
+			return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("less than or equal to exclusive minimum %v", schemaValue), Value: value, Tag: "exclusiveMinimum", Param: fmt.Sprintf("%v", schemaValue), SchemaValue: schemaValue}
+		}
+		return true, nil
+	}
 	v, ok := toFloat64(value)
 	if !ok {
 		return false, &errors.ValidationError{Path: path, Message: "must be a number", Tag: "exclusiveMinimum"}
@@ -60,13 +103,19 @@ func validateExclusiveMinimum(ctx context.Context, value interface{}, schemaValu
 		return false, &errors.ValidationError{Path: path, Message: "exclusiveMinimum must be a number", Tag: "exclusiveMinimum"}
 	}
 	if v <= min {
-		return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("less than or equal to exclusive minimum %v", min), Tag: "exclusiveMinimum", Param: fmt.Sprintf("%v", min)}
+		return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("less than or equal to exclusive minimum %v", min), Tag: "exclusiveMinimum", Param: fmt.Sprintf("%v", min), SchemaValue: schemaValue}
 	}
 	return true, nil
 }
 
 // validateExclusiveMaximum 验证数值严格小于最大值
 func validateExclusiveMaximum(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	if satisfied, handled := compareCustomNumber(ctx, value, schemaValue, "lt"); handled {
+		if !satisfied {
+			return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("greater than or equal to exclusive maximum %v", schemaValue), Value: value, Tag: "exclusiveMaximum", Param: fmt.Sprintf("%v", schemaValue), SchemaValue: schemaValue}
+		}
+		return true, nil
+	}
 	v, ok := toFloat64(value)
 	if !ok {
 		return false, &errors.ValidationError{Path: path, Message: "must be a number", Tag: "exclusiveMaximum"}
@@ -76,7 +125,7 @@ func validateExclusiveMaximum(ctx context.Context, value interface{}, schemaValu
 		return false, &errors.ValidationError{Path: path, Message: "exclusiveMaximum must be a number", Tag: "exclusiveMaximum"}
 	}
 	if v >= max {
-		return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("greater than or equal to exclusive maximum %v", max), Tag: "exclusiveMaximum", Param: fmt.Sprintf("%v", max)}
+		return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("greater than or equal to exclusive maximum %v", max), Tag: "exclusiveMaximum", Param: fmt.Sprintf("%v", max), SchemaValue: schemaValue}
 	}
 	return true, nil
 }
@@ -94,6 +143,30 @@ func validateMultipleOf(ctx context.Context, value interface{}, schemaValue inte
 		}
 	}
 
+	// numberParser 解码出的自定义数值按十进制精确计算
+	if isCustomNumber(ctx, value) {
+		multiple, ok := customMultipleOf(value, divisor)
+		if !ok {
+			return false, &errors.ValidationError{
+				Path:    path,
+				Message: fmt.Sprintf("multipleOf cannot be applied to %T: its string form is not a decimal number", value),
+				Value:   value,
+				Tag:     "multipleOf",
+			}
+		}
+		if !multiple {
+			return false, &errors.ValidationError{
+				Path:        path,
+				Message:     fmt.Sprintf("value %v is not a multiple of %v", value, divisor),
+				Value:       value,
+				Tag:         "multipleOf",
+				Param:       fmt.Sprintf("%v", divisor),
+				SchemaValue: schemaValue,
+			}
+		}
+		return true, nil
+	}
+
 	// 获取待验证的值
 	val, ok := toFloat64(value)
 	if !ok {
@@ -109,13 +182,193 @@ func validateMultipleOf(ctx context.Context, value interface{}, schemaValue inte
 	ratio := val / divisor
 	if math.Abs(ratio-math.Round(ratio)) > 1e-10 {
 		return false, &errors.ValidationError{
-			Path:    path,
-			Message: fmt.Sprintf("value %v is not a multiple of %v", value, divisor),
-			Value:   value,
-			Tag:     "multipleOf",
-			Param:   fmt.Sprintf("%v", divisor),
+			Path:        path,
+			Message:     fmt.Sprintf("value %v is not a multiple of %v", value, divisor),
+			Value:       value,
+			Tag:         "multipleOf",
+			Param:       fmt.Sprintf("%v", divisor),
+			SchemaValue: schemaValue,
 		}
 	}
 
 	return true, nil
 }
+
+// validateMaxDecimals 验证数值的小数位数不超过指定值
+func validateMaxDecimals(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	max, ok := toInt(schemaValue)
+	if !ok || max < 0 {
+		return false, &errors.ValidationError{Path: path, Message: "maxDecimals must be a non-negative integer", Tag: "maxDecimals"}
+	}
+	places, ok := decimalPlaces(value)
+	if !ok {
+		return false, &errors.ValidationError{Path: path, Message: "must be a number", Tag: "maxDecimals"}
+	}
+	if places > max {
+		return false, &errors.ValidationError{
+			Path:        path,
+			Message:     fmt.Sprintf("number has %d decimal places, maximum is %d", places, max),
+			Value:       value,
+			Tag:         "maxDecimals",
+			Param:       fmt.Sprintf("%d", max),
+			SchemaValue: schemaValue,
+		}
+	}
+	return true, nil
+}
+
+// decimalPlaces 返回数值的小数位数；json.Number 优先使用原始文本，浮点数使用最短十进制表示
+func decimalPlaces(value interface{}) (int, bool) {
+	var text string
+	switch v := value.(type) {
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return 0, false
+		}
+		text = v.String()
+		if strings.ContainsAny(text, "eE") {
+			text = strconv.FormatFloat(f, 'f', -1, 64)
+		}
+	case float64:
+		text = strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		text = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	default:
+		if _, ok := toNumber(value); !ok {
+			return 0, false
+		}
+		// 整数没有小数位
+		return 0, true
+	}
+	dot := strings.IndexByte(text, '.')
+	if dot < 0 {
+		return 0, true
+	}
+	return len(strings.TrimRight(text[dot+1:], "0")), true
+}
+
+// numberParserFrom 返回上下文中通过 WithNumberParser 配置的数值解析函数
+func numberParserFrom(ctx context.Context) func(json.Number) (interface{}, error) {
+	parser, _ := ctx.Value("numberParser").(func(json.Number) (interface{}, error))
+	return parser
+}
+
+// isCustomNumber 检查值是否为 numberParser 解码出的自定义数值类型（例如十进制金额），
+// 即配置了 numberParser 且值不是任何内置的JSON值类型
+func isCustomNumber(ctx context.Context, value interface{}) bool {
+	if numberParserFrom(ctx) == nil {
+		return false
+	}
+	switch value.(type) {
+	case nil, string, bool, json.Number, map[string]interface{}, []interface{}:
+		return false
+	}
+	_, ok := toFloat64(value)
+	return !ok
+}
+
+// compareCustomNumber 对自定义数值类型使用 numberParser 解析 schema 中的边界，并交给已注册的比较器 op 比较，
+// 应用可以为 gt/ge/lt/le 等比较器注册支持该类型的实现；handled 为 false 表示值不是自定义数值，应按 float64 处理
+func compareCustomNumber(ctx context.Context, value interface{}, bound interface{}, op string) (satisfied bool, handled bool) {
+	if !isCustomNumber(ctx, value) {
+		return false, false
+	}
+	reference, ok := parseCustomNumber(ctx, bound)
+	if !ok {
+		return false, true
+	}
+	registry, ok := ctx.Value("validator").(comparators.ComparatorRegistry)
+	if !ok {
+		return false, true
+	}
+	compare := registry.GetComparator(op)
+	return compare != nil && compare(value, reference), true
+}
+
+// parseCustomNumber 使用 numberParser 将 schema 中的数值转换为自定义数值类型
+func parseCustomNumber(ctx context.Context, value interface{}) (interface{}, bool) {
+	f, ok := toFloat64(value)
+	if !ok {
+		return nil, false
+	}
+	parsed, err := numberParserFrom(ctx)(json.Number(strconv.FormatFloat(f, 'f', -1, 64)))
+	return parsed, err == nil
+}
+
+// customNumbersEqual 在任一值为自定义数值时使用已注册的 eq 比较器判断相等，另一侧的JSON数值先经 numberParser 转换；
+// handled 为 false 表示两侧都不是自定义数值，应按JSON语义比较
+func customNumbersEqual(ctx context.Context, a, b interface{}) (equal bool, handled bool) {
+	aCustom, bCustom := isCustomNumber(ctx, a), isCustomNumber(ctx, b)
+	if !aCustom && !bCustom {
+		return false, false
+	}
+	var ok bool
+	if !aCustom {
+		if a, ok = parseCustomNumber(ctx, a); !ok {
+			return false, true
+		}
+	}
+	if !bCustom {
+		if b, ok = parseCustomNumber(ctx, b); !ok {
+			return false, true
+		}
+	}
+	registry, ok := ctx.Value("validator").(comparators.ComparatorRegistry)
+	if !ok {
+		return false, true
+	}
+	compare := registry.GetComparator("eq")
+	return compare != nil && compare(a, b), true
+}
+
+// deepEqualJSONCtx 与 deepEqualJSON 相同，但配置了 numberParser 时自定义数值（包括嵌套在对象和数组中的）通过 eq 比较器比较，
+// 供 enum、const、uniqueItems 使用
+func deepEqualJSONCtx(ctx context.Context, a, b interface{}) bool {
+	if numberParserFrom(ctx) == nil {
+		return deepEqualJSON(a, b)
+	}
+	if equal, handled := customNumbersEqual(ctx, a, b); handled {
+		return equal
+	}
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for k, v := range av {
+			other, exists := bv[k]
+			if !exists || !deepEqualJSONCtx(ctx, v, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !deepEqualJSONCtx(ctx, av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return deepEqualJSON(a, b)
+}
+
+// customMultipleOf 判断自定义数值是否为 divisor 的倍数：按值的十进制字符串形式（fmt.Sprint）精确计算，
+// 不经过 float64；ok 为 false 表示该值的字符串形式不是十进制数
+func customMultipleOf(value interface{}, divisor float64) (multiple bool, ok bool) {
+	val, ok := new(big.Rat).SetString(fmt.Sprint(value))
+	if !ok {
+		return false, false
+	}
+	div, ok := new(big.Rat).SetString(strconv.FormatFloat(divisor, 'f', -1, 64))
+	if !ok {
+		return false, false
+	}
+	return new(big.Rat).Quo(val, div).IsInt(), true
+}
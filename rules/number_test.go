@@ -2,8 +2,11 @@ package rules
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"testing"
 
+	"github.com/songzhibin97/jsonschema-validator/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -177,3 +180,73 @@ func TestValidateMultipleOf(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateMaxDecimals(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		value       interface{}
+		schemaValue interface{}
+		expectValid bool
+		expectErr   string
+	}{
+		{"Two decimals", 19.99, 2, true, ""},
+		{"Three decimals", 19.999, 2, false, "number has 3 decimal places, maximum is 2"},
+		{"Integer", 20, 2, true, ""},
+		{"Integral float", 20.0, 0, true, ""},
+		{"JSON number text", json.Number("19.99"), 2, true, ""},
+		{"JSON number too precise", json.Number("0.125"), 2, false, "number has 3 decimal places"},
+		{"JSON number trailing zeros", json.Number("1.500"), 1, true, ""},
+		{"JSON number exponent", json.Number("1.5e-3"), 3, false, "number has 4 decimal places"},
+		{"Not a number", "19.99", 2, false, "must be a number"},
+		{"Invalid schema", 1.5, -1, false, "maxDecimals must be a non-negative integer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := validateMaxDecimals(ctx, tt.value, tt.schemaValue, "root")
+			assert.Equal(t, tt.expectValid, valid)
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestNumericBoundAliases(t *testing.T) {
+	registry := NewRegistry()
+	registerNumberRules(registry)
+	ctx := context.Background()
+
+	tests := []struct {
+		tag         string
+		value       interface{}
+		bound       interface{}
+		expectValid bool
+	}{
+		{"gte", 17, 18, false},
+		{"gte", 18, 18, true},
+		{"gt", 18, 18, false},
+		{"gt", 19, 18, true},
+		{"lte", 120, 120, true},
+		{"lte", 121, 120, false},
+		{"lt", 120, 120, false},
+		{"lt", 119.5, 120, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %v %v", tt.tag, tt.value, tt.bound), func(t *testing.T) {
+			valid, err := registry.Get(tt.tag)(ctx, tt.value, tt.bound, "root")
+			assert.Equal(t, tt.expectValid, valid)
+			if tt.expectValid {
+				assert.NoError(t, err)
+			} else if ve, ok := err.(*errors.ValidationError); assert.True(t, ok) {
+				assert.Equal(t, tt.tag, ve.Tag)
+			}
+		})
+	}
+}
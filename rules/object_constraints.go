@@ -3,6 +3,9 @@ package rules
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/songzhibin97/jsonschema-validator/errors"
 )
@@ -20,8 +23,8 @@ func validateMinProperties(ctx context.Context, value interface{}, schemaValue i
 		}
 	}
 
-	// 获取对象
-	obj, ok := value.(map[string]interface{})
+	// 获取对象，结构体字段中的任意映射类型同样按键数量计算
+	count, ok := propertyCount(value)
 	if !ok {
 		return false, &errors.ValidationError{
 			Path:    path,
@@ -31,10 +34,10 @@ func validateMinProperties(ctx context.Context, value interface{}, schemaValue i
 		}
 	}
 
-	if len(obj) < minProperties {
+	if count < minProperties {
 		return false, &errors.ValidationError{
 			Path:    path,
-			Message: fmt.Sprintf("object has %d properties, which is less than minProperties %d", len(obj), minProperties),
+			Message: fmt.Sprintf("object has %d properties, which is less than minProperties %d", count, minProperties),
 			Value:   value,
 			Tag:     "minProperties",
 			Param:   fmt.Sprintf("%d", minProperties),
@@ -57,8 +60,8 @@ func validateMaxProperties(ctx context.Context, value interface{}, schemaValue i
 		}
 	}
 
-	// 获取对象
-	obj, ok := value.(map[string]interface{})
+	// 获取对象，结构体字段中的任意映射类型同样按键数量计算
+	count, ok := propertyCount(value)
 	if !ok {
 		return false, &errors.ValidationError{
 			Path:    path,
@@ -68,10 +71,10 @@ func validateMaxProperties(ctx context.Context, value interface{}, schemaValue i
 		}
 	}
 
-	if len(obj) > maxProperties {
+	if count > maxProperties {
 		return false, &errors.ValidationError{
 			Path:    path,
-			Message: fmt.Sprintf("object has %d properties, which is more than maxProperties %d", len(obj), maxProperties),
+			Message: fmt.Sprintf("object has %d properties, which is more than maxProperties %d", count, maxProperties),
 			Value:   value,
 			Tag:     "maxProperties",
 			Param:   fmt.Sprintf("%d", maxProperties),
@@ -80,3 +83,113 @@ func validateMaxProperties(ctx context.Context, value interface{}, schemaValue i
 
 	return true, nil
 }
+
+// propertyCount 返回对象（或结构体字段中的映射）的属性数量，值不是映射时返回 false
+func propertyCount(value interface{}) (int, bool) {
+	if obj, ok := value.(map[string]interface{}); ok {
+		return len(obj), true
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Map {
+		return 0, false
+	}
+	return rv.Len(), true
+}
+
+// validateNonEmpty 验证对象至少包含一个属性，等价于 minProperties: 1
+func validateNonEmpty(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	return validateObjectEmptiness(value, schemaValue, path, "nonEmpty", false)
+}
+
+// validateEmpty 验证对象不包含任何属性，等价于 maxProperties: 0
+func validateEmpty(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	return validateObjectEmptiness(value, schemaValue, path, "empty", true)
+}
+
+// validateObjectEmptiness 检查对象（或结构体字段中的映射）是否为空
+func validateObjectEmptiness(value interface{}, schemaValue interface{}, path string, tag string, wantEmpty bool) (bool, error) {
+	enabled, ok := schemaValue.(bool)
+	if !ok {
+		return false, &errors.ValidationError{
+			Path:    path,
+			Message: fmt.Sprintf("%s must be a boolean", tag),
+			Value:   schemaValue,
+			Tag:     tag,
+		}
+	}
+	if !enabled {
+		return true, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Map {
+		return false, &errors.ValidationError{
+			Path:    path,
+			Message: fmt.Sprintf("%s can only be applied to objects", tag),
+			Value:   value,
+			Tag:     tag,
+		}
+	}
+
+	if empty := rv.Len() == 0; empty != wantEmpty {
+		message := "object must not be empty"
+		if wantEmpty {
+			message = fmt.Sprintf("object must be empty, got %d properties", rv.Len())
+		}
+		return false, &errors.ValidationError{
+			Path:        path,
+			Message:     message,
+			Value:       value,
+			Tag:         tag,
+			SchemaValue: schemaValue,
+		}
+	}
+	return true, nil
+}
+
+// validateAllowedProperties 验证对象的所有键都在允许的列表中，不约束属性值
+func validateAllowedProperties(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	allowed, ok := toStringSlice(schemaValue)
+	if !ok {
+		return false, &errors.ValidationError{
+			Path:    path,
+			Message: "allowedProperties must be an array of strings",
+			Value:   schemaValue,
+			Tag:     "allowedProperties",
+		}
+	}
+
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return false, &errors.ValidationError{
+			Path:    path,
+			Message: "allowedProperties can only be applied to objects",
+			Value:   value,
+			Tag:     "allowedProperties",
+		}
+	}
+
+	allowedSet := make(map[string]bool, len(allowed))
+	for _, key := range allowed {
+		allowedSet[key] = true
+	}
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	// 按键名排序，保证报告的第一个不允许的键是确定的
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !allowedSet[key] {
+			return false, &errors.ValidationError{
+				Path:        path + "." + key,
+				Message:     fmt.Sprintf("property '%s' is not allowed", key),
+				Value:       obj[key],
+				Tag:         "allowedProperties",
+				Param:       strings.Join(allowed, ","),
+				SchemaValue: schemaValue,
+			}
+		}
+	}
+	return true, nil
+}
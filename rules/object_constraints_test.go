@@ -176,3 +176,73 @@ func TestValidateMaxProperties(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateEmptiness(t *testing.T) {
+	ctx := context.Background()
+	empty := map[string]interface{}{}
+	filled := map[string]interface{}{"a": 1}
+
+	tests := []struct {
+		name        string
+		fn          RuleFunc
+		value       interface{}
+		schemaValue interface{}
+		expectValid bool
+		expectErr   string
+	}{
+		{"nonEmpty rejects empty object", validateNonEmpty, empty, true, false, "object must not be empty"},
+		{"nonEmpty accepts filled object", validateNonEmpty, filled, true, true, ""},
+		{"nonEmpty false is no-op", validateNonEmpty, empty, false, true, ""},
+		{"empty accepts empty object", validateEmpty, empty, true, true, ""},
+		{"empty rejects filled object", validateEmpty, filled, true, false, "object must be empty, got 1 properties"},
+		{"Go map", validateNonEmpty, map[string]string{}, true, false, "object must not be empty"},
+		{"Not an object", validateEmpty, "x", true, false, "empty can only be applied to objects"},
+		{"Invalid schema", validateNonEmpty, filled, "yes", false, "nonEmpty must be a boolean"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := tt.fn(ctx, tt.value, tt.schemaValue, "root")
+			assert.Equal(t, tt.expectValid, valid)
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestValidateAllowedProperties(t *testing.T) {
+	ctx := context.Background()
+	allowed := []interface{}{"a", "b", "c"}
+
+	tests := []struct {
+		name        string
+		value       interface{}
+		schemaValue interface{}
+		expectValid bool
+		expectErr   string
+	}{
+		{"Subset of allowed keys", map[string]interface{}{"a": 1, "c": map[string]interface{}{"any": true}}, allowed, true, ""},
+		{"Empty object", map[string]interface{}{}, allowed, true, ""},
+		{"Extra key", map[string]interface{}{"a": 1, "z": 2}, allowed, false, "property 'z' is not allowed"},
+		{"First disallowed key reported", map[string]interface{}{"y": 1, "x": 2}, allowed, false, "property 'x' is not allowed"},
+		{"Not an object", []interface{}{"a"}, allowed, false, "allowedProperties can only be applied to objects"},
+		{"Invalid schema", map[string]interface{}{}, []interface{}{1}, false, "allowedProperties must be an array of strings"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := validateAllowedProperties(ctx, tt.value, tt.schemaValue, "root")
+			assert.Equal(t, tt.expectValid, valid)
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+			}
+		})
+	}
+}
@@ -103,3 +103,64 @@ func validateDependencies(ctx context.Context, value interface{}, schemaValue in
 
 	return true, nil
 }
+
+// validateRequiredIfMatch 当 field 指定的字符串属性匹配 pattern 时，要求 require 中的属性都存在，
+// 与 required 一样报告每个缺失的属性，例如 {"field": "email", "pattern": "@corp\\.com$", "require": ["employeeId"]}
+func validateRequiredIfMatch(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	cond, ok := schemaValue.(map[string]interface{})
+	if !ok {
+		return false, &errors.ValidationError{Path: path, Message: "requiredIfMatch must be an object", Value: schemaValue, Tag: "requiredIfMatch"}
+	}
+	field, _ := cond["field"].(string)
+	pattern, _ := cond["pattern"].(string)
+	required, ok := toStringSlice(cond["require"])
+	if field == "" || !ok {
+		return false, &errors.ValidationError{
+			Path:    path,
+			Message: "requiredIfMatch requires a field name, a pattern and a require array of strings",
+			Value:   schemaValue,
+			Tag:     "requiredIfMatch",
+		}
+	}
+	re, err := patternRegexp(ctx, pattern)
+	if err != nil {
+		return false, &errors.ValidationError{
+			Path:    path,
+			Message: fmt.Sprintf("invalid requiredIfMatch pattern: %v", err),
+			Value:   pattern,
+			Tag:     "requiredIfMatch",
+		}
+	}
+
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return false, &errors.ValidationError{
+			Path:    path,
+			Message: "requiredIfMatch can only be applied to objects",
+			Value:   value,
+			Tag:     "requiredIfMatch",
+		}
+	}
+
+	// 条件字段不存在或不是匹配的字符串时不要求任何属性
+	str, ok := obj[field].(string)
+	if !ok || !re.MatchString(str) {
+		return true, nil
+	}
+	var missing errors.ValidationErrors
+	for _, req := range required {
+		if _, exists := obj[req]; !exists {
+			missing = append(missing, errors.ValidationError{
+				Path:        path + "." + req,
+				Message:     fmt.Sprintf("property '%s' is required when '%s' matches '%s'", req, field, pattern),
+				Tag:         "requiredIfMatch",
+				Param:       req,
+				SchemaValue: schemaValue,
+			})
+		}
+	}
+	if len(missing) > 0 {
+		return false, missing
+	}
+	return true, nil
+}
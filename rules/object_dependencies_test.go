@@ -162,3 +162,39 @@ func TestValidateDependencies(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateRequiredIfMatch(t *testing.T) {
+	ctx := context.Background()
+	corp := map[string]interface{}{"field": "email", "pattern": `@corp\.com$`, "require": []interface{}{"employeeId"}}
+
+	tests := []struct {
+		name        string
+		value       interface{}
+		schemaValue interface{}
+		expectValid bool
+		expectErr   string
+	}{
+		{"Matching email with employeeId", map[string]interface{}{"email": "a@corp.com", "employeeId": "E1"}, corp, true, ""},
+		{"Matching email without employeeId", map[string]interface{}{"email": "a@corp.com"}, corp, false, "property 'employeeId' is required when 'email' matches"},
+		{"Every missing property reported", map[string]interface{}{"email": "a@corp.com"}, map[string]interface{}{"field": "email", "pattern": `@corp\.com$`, "require": []interface{}{"employeeId", "costCenter"}}, false, "property 'costCenter' is required when 'email' matches"},
+		{"Non-matching email", map[string]interface{}{"email": "a@gmail.com"}, corp, true, ""},
+		{"Missing field", map[string]interface{}{}, corp, true, ""},
+		{"Non-string field", map[string]interface{}{"email": 1}, corp, true, ""},
+		{"Non-object value", "a@corp.com", corp, false, "requiredIfMatch can only be applied to objects"},
+		{"Invalid pattern", map[string]interface{}{}, map[string]interface{}{"field": "email", "pattern": "(", "require": []interface{}{"id"}}, false, "invalid requiredIfMatch pattern"},
+		{"Missing require", map[string]interface{}{}, map[string]interface{}{"field": "email", "pattern": "x"}, false, "requiredIfMatch requires"},
+		{"Invalid schema value", map[string]interface{}{}, "email", false, "requiredIfMatch must be an object"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := validateRequiredIfMatch(ctx, tt.value, tt.schemaValue, "root")
+			assert.Equal(t, tt.expectValid, valid)
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.expectErr)
+			}
+		})
+	}
+}
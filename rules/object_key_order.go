@@ -0,0 +1,90 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/songzhibin97/jsonschema-validator/errors"
+)
+
+// validateKeyOrder 验证对象中出现的键按指定的相对顺序排列
+// 键的原始顺序需要由验证器通过上下文中的 keyOrders 提供，以解码后对象的地址为索引
+func validateKeyOrder(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	expected, ok := toStringSlice(schemaValue)
+	if !ok {
+		return false, &errors.ValidationError{
+			Path:    path,
+			Message: "keyOrder must be an array of strings",
+			Value:   schemaValue,
+			Tag:     "keyOrder",
+		}
+	}
+
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return false, &errors.ValidationError{
+			Path:    path,
+			Message: "keyOrder can only be applied to objects",
+			Value:   value,
+			Tag:     "keyOrder",
+		}
+	}
+
+	orders, ok := ctx.Value("keyOrders").(map[uintptr][]string)
+	if !ok {
+		return false, &errors.ValidationError{
+			Path:    path,
+			Message: "keyOrder requires the original key order; enable key order preservation on the validator",
+			Tag:     "keyOrder",
+		}
+	}
+
+	position := make(map[string]int, len(expected))
+	for i, key := range expected {
+		position[key] = i
+	}
+
+	// 只比较出现在 keyOrder 中的键
+	actual := make([]string, 0, len(expected))
+	for _, key := range orders[reflect.ValueOf(obj).Pointer()] {
+		if _, listed := position[key]; listed {
+			actual = append(actual, key)
+		}
+	}
+	for i := 1; i < len(actual); i++ {
+		if position[actual[i-1]] > position[actual[i]] {
+			return false, &errors.ValidationError{
+				Path:        path,
+				Message:     fmt.Sprintf("key '%s' must appear before '%s'", actual[i], actual[i-1]),
+				Value:       actual,
+				Tag:         "keyOrder",
+				Param:       strings.Join(expected, ","),
+				SchemaValue: schemaValue,
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// toStringSlice 将字符串数组转换为 []string
+func toStringSlice(value interface{}) ([]string, bool) {
+	switch v := value.(type) {
+	case []string:
+		return v, true
+	case []interface{}:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			str, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			result = append(result, str)
+		}
+		return result, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,50 @@
+package rules
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateKeyOrder(t *testing.T) {
+	obj := map[string]interface{}{"id": 1, "amount": 2, "currency": "EUR", "extra": true}
+	wrong := map[string]interface{}{"currency": "EUR", "id": 1, "extra": true}
+	orders := map[uintptr][]string{
+		reflect.ValueOf(obj).Pointer():   {"id", "amount", "currency", "extra"},
+		reflect.ValueOf(wrong).Pointer(): {"currency", "id", "extra"},
+	}
+	ctx := context.WithValue(context.Background(), "keyOrders", orders)
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		value       interface{}
+		schemaValue interface{}
+		path        string
+		expectValid bool
+		expectErr   string
+	}{
+		{"In order", ctx, obj, []interface{}{"id", "amount", "currency"}, "root", true, ""},
+		{"Subset in order", ctx, obj, []interface{}{"id", "currency"}, "root", true, ""},
+		{"Unlisted keys ignored", ctx, wrong, []interface{}{"id", "currency"}, "root", false, "key 'id' must appear before 'currency'"},
+		{"Out of order", ctx, obj, []interface{}{"amount", "id"}, "root", false, "key 'amount' must appear before 'id'"},
+		{"Invalid schema", ctx, obj, []interface{}{1}, "root", false, "keyOrder must be an array of strings"},
+		{"Not an object", ctx, "x", []interface{}{"id"}, "root", false, "keyOrder can only be applied to objects"},
+		{"No order information", context.Background(), obj, []interface{}{"id"}, "root", false, "requires the original key order"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := validateKeyOrder(tt.ctx, tt.value, tt.schemaValue, tt.path)
+			assert.Equal(t, tt.expectValid, valid)
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+			}
+		})
+	}
+}
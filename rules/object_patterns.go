@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"sort"
 
 	"github.com/songzhibin97/jsonschema-validator/errors"
 )
@@ -61,6 +62,78 @@ func validatePatternProperties(ctx context.Context, value interface{}, schemaVal
 	return true, nil
 }
 
+// validateMinPatternMatches 验证匹配每个模式的键数量不少于指定值
+func validateMinPatternMatches(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	return validatePatternMatchCount(value, schemaValue, path, "minPatternMatches")
+}
+
+// validateMaxPatternMatches 验证匹配每个模式的键数量不超过指定值
+func validateMaxPatternMatches(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	return validatePatternMatchCount(value, schemaValue, path, "maxPatternMatches")
+}
+
+// validatePatternMatchCount 统计匹配每个模式的键数量并与限制比较
+func validatePatternMatchCount(value interface{}, schemaValue interface{}, path string, keyword string) (bool, error) {
+	limits, ok := schemaValue.(map[string]interface{})
+	if !ok {
+		return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("%s must be an object", keyword), Value: schemaValue, Tag: keyword}
+	}
+
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("%s can only be applied to objects", keyword), Value: value, Tag: keyword}
+	}
+
+	compiledPatterns, err := compilePatterns(limits)
+	if err != nil {
+		return false, &errors.ValidationError{Path: path, Message: err.Error(), Value: limits, Tag: keyword}
+	}
+
+	// 按模式排序，保证错误报告稳定
+	patterns := make([]string, 0, len(limits))
+	for pattern := range limits {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+
+	for _, pattern := range patterns {
+		limit, ok := toInt(limits[pattern])
+		if !ok || limit < 0 {
+			return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("%s limit for pattern '%s' must be a non-negative integer", keyword, pattern), Value: limits[pattern], Tag: keyword}
+		}
+
+		count := 0
+		for propName := range obj {
+			if compiledPatterns[pattern].MatchString(propName) {
+				count++
+			}
+		}
+
+		if keyword == "maxPatternMatches" && count > limit {
+			return false, &errors.ValidationError{
+				Path:        path,
+				Message:     fmt.Sprintf("%d properties match pattern '%s', which is more than the maximum %d", count, pattern, limit),
+				Value:       value,
+				Tag:         keyword,
+				Param:       pattern,
+				SchemaValue: schemaValue,
+			}
+		}
+		if keyword == "minPatternMatches" && count < limit {
+			return false, &errors.ValidationError{
+				Path:        path,
+				Message:     fmt.Sprintf("%d properties match pattern '%s', which is less than the minimum %d", count, pattern, limit),
+				Value:       value,
+				Tag:         keyword,
+				Param:       pattern,
+				SchemaValue: schemaValue,
+			}
+		}
+	}
+
+	return true, nil
+}
+
 func validateAdditionalProperties(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
 	obj, ok := value.(map[string]interface{})
 	if !ok {
@@ -205,7 +278,7 @@ func validateSchemaForProperty(ctx context.Context, value interface{}, schema ma
 func compilePatterns(patterns map[string]interface{}) (map[string]*regexp.Regexp, error) {
 	result := make(map[string]*regexp.Regexp)
 	for pattern := range patterns {
-		re, err := regexp.Compile(pattern)
+		re, err := cachedRegexp(pattern)
 		if err != nil {
 			return nil, fmt.Errorf("invalid pattern: %s", err.Error())
 		}
@@ -279,3 +279,39 @@ func TestValidateAdditionalProperties(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatePatternMatchCount(t *testing.T) {
+	ctx := context.Background()
+	obj := map[string]interface{}{"x-a": 1, "x-b": 2, "x-c": 3, "name": "n"}
+
+	tests := []struct {
+		name        string
+		fn          RuleFunc
+		schemaValue interface{}
+		value       interface{}
+		expectValid bool
+		expectErr   string
+	}{
+		{"Max compliant", validateMaxPatternMatches, map[string]interface{}{"^x-": 3.0}, obj, true, ""},
+		{"Max exceeded", validateMaxPatternMatches, map[string]interface{}{"^x-": 2.0}, obj, false, "3 properties match pattern '^x-', which is more than the maximum 2"},
+		{"Min compliant", validateMinPatternMatches, map[string]interface{}{"^x-": 1}, obj, true, ""},
+		{"Min not reached", validateMinPatternMatches, map[string]interface{}{"^y-": 1}, obj, false, "0 properties match pattern '^y-', which is less than the minimum 1"},
+		{"Invalid limit", validateMaxPatternMatches, map[string]interface{}{"^x-": -1}, obj, false, "must be a non-negative integer"},
+		{"Invalid pattern", validateMaxPatternMatches, map[string]interface{}{"[": 1}, obj, false, "invalid pattern"},
+		{"Invalid schema", validateMaxPatternMatches, 3, obj, false, "maxPatternMatches must be an object"},
+		{"Not an object", validateMaxPatternMatches, map[string]interface{}{"^x-": 1}, "x", false, "can only be applied to objects"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := tt.fn(ctx, tt.value, tt.schemaValue, "root")
+			assert.Equal(t, tt.expectValid, valid)
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/songzhibin97/jsonschema-validator/errors"
+)
+
+// validateRefProperty 验证值引用了文档中已存在的键：schemaValue 形如 {"path": "/nodes", "property": "id"}，
+// 值必须等于根文档中 path（JSON Pointer）所指数组内某个对象的 property 属性，null 不做检查。
+// 根文档需要由验证器通过上下文中的 rootDocument 提供
+func validateRefProperty(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	spec, _ := schemaValue.(map[string]interface{})
+	pointer, pointerOK := spec["path"].(string)
+	property, propertyOK := spec["property"].(string)
+	if !pointerOK || !propertyOK {
+		return false, &errors.ValidationError{
+			Path:    path,
+			Message: "refProperty must be an object with string 'path' and 'property'",
+			Value:   schemaValue,
+			Tag:     "refProperty",
+		}
+	}
+
+	if value == nil {
+		return true, nil
+	}
+
+	root := ctx.Value("rootDocument")
+	if root == nil {
+		return false, &errors.ValidationError{
+			Path:    path,
+			Message: "refProperty requires the root document; validate with ValidateJSON or ValidateAgainst",
+			Tag:     "refProperty",
+		}
+	}
+
+	target, found := resolveJSONPointer(root, pointer)
+	items, isArray := target.([]interface{})
+	if !found || !isArray {
+		return false, &errors.ValidationError{
+			Path:        path,
+			Message:     fmt.Sprintf("refProperty path '%s' does not reference an array", pointer),
+			Tag:         "refProperty",
+			Param:       pointer,
+			SchemaValue: schemaValue,
+		}
+	}
+
+	for _, item := range items {
+		if obj, ok := item.(map[string]interface{}); ok {
+			if key, exists := obj[property]; exists && deepEqualJSONCtx(ctx, key, value) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, &errors.ValidationError{
+		Path:        path,
+		Message:     fmt.Sprintf("value %v does not reference an existing '%s' in '%s'", value, property, pointer),
+		Value:       value,
+		Tag:         "refProperty",
+		Param:       pointer + "/" + property,
+		SchemaValue: schemaValue,
+	}
+}
+
+// resolveJSONPointer 按 JSON Pointer 在解码后的文档中查找值，"" 表示整个文档
+func resolveJSONPointer(doc interface{}, pointer string) (interface{}, bool) {
+	if pointer == "" {
+		return doc, true
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, false
+	}
+	current := doc
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		switch node := current.(type) {
+		case map[string]interface{}:
+			next, ok := node[token]
+			if !ok {
+				return nil, false
+			}
+			current = next
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, false
+			}
+			current = node[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
@@ -0,0 +1,53 @@
+package rules
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateRefProperty(t *testing.T) {
+	root := map[string]interface{}{
+		"nodes": []interface{}{
+			map[string]interface{}{"id": float64(1)},
+			map[string]interface{}{"id": float64(2), "parentId": float64(1)},
+			"not an object",
+		},
+		"meta": map[string]interface{}{"a/b": []interface{}{map[string]interface{}{"code": "x"}}},
+	}
+	ctx := context.WithValue(context.Background(), "rootDocument", root)
+	spec := map[string]interface{}{"path": "/nodes", "property": "id"}
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		value       interface{}
+		schemaValue interface{}
+		expectValid bool
+		expectErr   string
+	}{
+		{"Existing reference", ctx, float64(1), spec, true, ""},
+		{"Null is not checked", ctx, nil, spec, true, ""},
+		{"Escaped pointer", ctx, "x", map[string]interface{}{"path": "/meta/a~1b", "property": "code"}, true, ""},
+		{"Dangling reference", ctx, float64(3), spec, false, "value 3 does not reference an existing 'id' in '/nodes'"},
+		{"Different type", ctx, "1", spec, false, "does not reference an existing 'id'"},
+		{"Path not an array", ctx, float64(1), map[string]interface{}{"path": "/meta", "property": "id"}, false, "refProperty path '/meta' does not reference an array"},
+		{"Missing path", ctx, float64(1), map[string]interface{}{"path": "/missing", "property": "id"}, false, "does not reference an array"},
+		{"Invalid schema", ctx, float64(1), "/nodes", false, "refProperty must be an object"},
+		{"No root document", context.Background(), float64(1), spec, false, "requires the root document"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := validateRefProperty(tt.ctx, tt.value, tt.schemaValue, "root.nodes[1].parentId")
+			assert.Equal(t, tt.expectValid, valid)
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+			}
+		})
+	}
+}
@@ -9,11 +9,23 @@ func registerObjectRules(registry ValidatorRegistry) {
 	// 约束验证
 	registry.RegisterValidator("minProperties", validateMinProperties)
 	registry.RegisterValidator("maxProperties", validateMaxProperties)
+	registry.RegisterValidator("nonEmpty", validateNonEmpty)
+	registry.RegisterValidator("empty", validateEmpty)
+	registry.RegisterValidator("allowedProperties", validateAllowedProperties)
 
 	// 模式属性验证
 	registry.RegisterValidator("patternProperties", validatePatternProperties)
 	registry.RegisterValidator("additionalProperties", validateAdditionalProperties)
+	registry.RegisterValidator("minPatternMatches", validateMinPatternMatches)
+	registry.RegisterValidator("maxPatternMatches", validateMaxPatternMatches)
 
 	// 依赖关系验证
 	registry.RegisterValidator("dependencies", validateDependencies)
+	registry.RegisterValidator("requiredIfMatch", validateRequiredIfMatch)
+
+	// 键顺序验证
+	registry.RegisterValidator("keyOrder", validateKeyOrder)
+
+	// 文档内引用验证
+	registry.RegisterValidator("refProperty", validateRefProperty)
 }
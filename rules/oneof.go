@@ -0,0 +1,64 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/songzhibin97/jsonschema-validator/comparators"
+	"github.com/songzhibin97/jsonschema-validator/errors"
+)
+
+// 注册成员检查相关规则
+func registerOneOfRules(registry ValidatorRegistry) {
+	registry.RegisterValidator("oneof", validateOneOfValues)
+}
+
+// validateOneOfValues 验证值属于给定的数字或字符串列表，使用验证器中注册的 eq 比较器判断相等
+func validateOneOfValues(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	values, ok := schemaValue.([]interface{})
+	if !ok {
+		if strs, isStrings := toStringSlice(schemaValue); isStrings {
+			values = make([]interface{}, len(strs))
+			for i, s := range strs {
+				values[i] = s
+			}
+		} else {
+			return false, &errors.ValidationError{Path: path, Message: "oneof must be a list of values", Tag: "oneof"}
+		}
+	}
+
+	equal := comparators.CompareFunc(deepEqualJSON)
+	if registry, ok := ctx.Value("validator").(comparators.ComparatorRegistry); ok {
+		if eq := registry.GetComparator("eq"); eq != nil {
+			equal = eq
+		}
+	}
+
+	// 数值统一为 float64，使 int 字段能与标签中解析出的数字比较
+	target := value
+	if num, ok := toNumber(value); ok {
+		target = num
+	}
+	for _, candidate := range values {
+		if num, ok := toNumber(candidate); ok {
+			candidate = num
+		}
+		if equal(target, candidate) {
+			return true, nil
+		}
+	}
+
+	names := make([]string, len(values))
+	for i, candidate := range values {
+		names[i] = fmt.Sprintf("%v", candidate)
+	}
+	return false, &errors.ValidationError{
+		Path:        path,
+		Message:     fmt.Sprintf("value must be one of: %s", strings.Join(names, ", ")),
+		Value:       value,
+		Tag:         "oneof",
+		Param:       strings.Join(names, " "),
+		SchemaValue: schemaValue,
+	}
+}
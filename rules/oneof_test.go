@@ -0,0 +1,48 @@
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/songzhibin97/jsonschema-validator/comparators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateOneOfValues(t *testing.T) {
+	registry := comparators.NewSimpleComparatorRegistry()
+	assert.NoError(t, comparators.RegisterBuiltInComparators(registry))
+	ctx := context.WithValue(context.Background(), "validator", registry)
+
+	numbers := []interface{}{10.0, 20.0, 30.0}
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		value       interface{}
+		schemaValue interface{}
+		expectValid bool
+		expectErr   string
+	}{
+		{"Int member", ctx, 20, numbers, true, ""},
+		{"Int not member", ctx, 25, numbers, false, "value must be one of: 10, 20, 30"},
+		{"JSON number member", ctx, json.Number("30"), numbers, true, ""},
+		{"String member", ctx, "red", []interface{}{"red", "green"}, true, ""},
+		{"String not member", ctx, "blue", []interface{}{"red", "green"}, false, "value must be one of: red, green"},
+		{"String slice schema", ctx, "green", []string{"red", "green"}, true, ""},
+		{"Without comparator registry", context.Background(), 10, numbers, true, ""},
+		{"Invalid schema", ctx, 10, 10, false, "oneof must be a list of values"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := validateOneOfValues(tt.ctx, tt.value, tt.schemaValue, "root")
+			assert.Equal(t, tt.expectValid, valid)
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+			}
+		})
+	}
+}
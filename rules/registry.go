@@ -105,7 +105,15 @@ func RegisterBuiltInRules(registry ValidatorRegistry) {
 	registerFormatRules(registry)
 	registerLogicalRules(registry)
 	registerConditionalRules(registry)
-
+	registerConstRules(registry)
+	registerContentRules(registry)
+	registerCompareRules(registry)
+	registerOneOfRules(registry)
+	registerLengthRules(registry)
+	registerCharClassRules(registry)
+	registerGeoRules(registry)
+	registerDateTimeRules(registry)
+	registerContextRules(registry)
 }
 
 // RegisterAll 注册所有内置规则到默认注册表
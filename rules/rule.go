@@ -81,7 +81,7 @@ func requiredValidator(ctx context.Context, value interface{}, schemaValue inter
 	if schemaValue == nil {
 		return true, nil
 	}
-	requiredFields, ok := schemaValue.([]string)
+	requiredFields, ok := toStringSlice(schemaValue)
 	if !ok {
 		return false, fmt.Errorf("required must be an array of strings")
 	}
@@ -120,6 +120,19 @@ func minimumValidator(ctx context.Context, value interface{}, schema interface{}
 			Tag:     "minimum",
 		}
 	}
+	if satisfied, handled := compareCustomNumber(ctx, value, schemaNum, "ge"); handled {
+		if !satisfied {
+			return false, &errors.ValidationError{
+				Path:        path,
+				Message:     fmt.Sprintf("value %v is less than minimum %v", value, schemaNum),
+				Tag:         "minimum",
+				Value:       value,
+				Param:       fmt.Sprintf("%v", schemaNum),
+				SchemaValue: schema,
+			}
+		}
+		return true, nil
+	}
 	valueNum, ok := toFloat64(value)
 	if !ok {
 		return false, &errors.ValidationError{
@@ -131,10 +144,12 @@ func minimumValidator(ctx context.Context, value interface{}, schema interface{}
 	}
 	if valueNum < schemaNum {
 		return false, &errors.ValidationError{
-			Path:    path,
-			Message: fmt.Sprintf("value %v is less than minimum %v", valueNum, schemaNum),
-			Tag:     "minimum",
-			Value:   value,
+			Path:        path,
+			Message:     fmt.Sprintf("value %v is less than minimum %v", valueNum, schemaNum),
+			Tag:         "minimum",
+			Value:       value,
+			Param:       fmt.Sprintf("%v", schemaNum),
+			SchemaValue: schema,
 		}
 	}
 	return true, nil
@@ -142,6 +157,17 @@ func minimumValidator(ctx context.Context, value interface{}, schema interface{}
 
 // enumValidator 验证枚举值
 func enumValidator(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	// 编译后的枚举（schema.EnumSet）对字符串值直接查集合，其他值和失败时的错误报告仍走线性比较
+	if set, ok := schemaValue.(enumLookup); ok {
+		if str, ok := value.(string); ok && set.HasString(str) {
+			return true, nil
+		}
+		return enumAnyValidator(ctx, value, set.Values(), path)
+	}
+	// JSON schema 中的枚举可以包含任意类型的值
+	if values, ok := schemaValue.([]interface{}); ok {
+		return enumAnyValidator(ctx, value, values, path)
+	}
 	enumValues, ok := schemaValue.([]string)
 	if !ok {
 		return false, fmt.Errorf("enum must be an array of strings")
@@ -160,9 +186,37 @@ func enumValidator(ctx context.Context, value interface{}, schemaValue interface
 		}
 	}
 	return false, &errors.ValidationError{
-		Path:    path,
-		Message: fmt.Sprintf("value must be one of: %s", strings.Join(enumValues, ", ")),
-		Tag:     "enum",
+		Path:        path,
+		Message:     fmt.Sprintf("value must be one of: %s", strings.Join(enumValues, ", ")),
+		Tag:         "enum",
+		Value:       value,
+		SchemaValue: schemaValue,
+	}
+}
+
+// enumLookup 由编译阶段预先构建的枚举集合实现，避免对大型字符串枚举逐个比较
+type enumLookup interface {
+	Values() []interface{}
+	HasString(string) bool
+}
+
+// enumAnyValidator 验证值与任意类型的枚举值之一按JSON语义相等
+func enumAnyValidator(ctx context.Context, value interface{}, enumValues []interface{}, path string) (bool, error) {
+	for _, v := range enumValues {
+		if deepEqualJSONCtx(ctx, value, v) {
+			return true, nil
+		}
+	}
+	names := make([]string, 0, len(enumValues))
+	for _, v := range enumValues {
+		names = append(names, fmt.Sprintf("%v", v))
+	}
+	return false, &errors.ValidationError{
+		Path:        path,
+		Message:     fmt.Sprintf("value must be one of: %s", strings.Join(names, ", ")),
+		Tag:         "enum",
+		Value:       value,
+		SchemaValue: enumValues,
 	}
 }
 
@@ -3,8 +3,10 @@ package rules
 import (
 	"context"
 	"fmt"
-	"reflect"
 	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
 
 	"github.com/songzhibin97/jsonschema-validator/errors"
 )
@@ -14,56 +16,181 @@ func registerStringRules(registry ValidatorRegistry) {
 	registry.RegisterValidator("minLength", validateMinLength)
 	registry.RegisterValidator("maxLength", validateMaxLength)
 	registry.RegisterValidator("pattern", validatePattern)
+	registry.RegisterValidator("minBytes", byteLengthRule("minBytes", "less than minimum", func(n, limit int) bool { return n >= limit }))
+	registry.RegisterValidator("maxBytes", byteLengthRule("maxBytes", "greater than maximum", func(n, limit int) bool { return n <= limit }))
+	registry.RegisterValidator("startswith", substringRule("startswith", "start with", strings.HasPrefix))
+	registry.RegisterValidator("endswith", substringRule("endswith", "end with", strings.HasSuffix))
+	registry.RegisterValidator("containssub", substringRule("containssub", "contain", strings.Contains))
+	registry.RegisterValidator("trimmed", validateTrimmed)
+}
+
+// validateTrimmed 验证字符串没有首尾空白字符（只检查不修改），schema 值为 false 时不做检查
+func validateTrimmed(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	str, ok, err := charClassInput("trimmed", value, schemaValue, path)
+	if !ok {
+		return err == nil, err
+	}
+	if strings.TrimSpace(str) != str {
+		return false, &errors.ValidationError{
+			Path:        path,
+			Message:     "value must not have leading or trailing whitespace",
+			Value:       value,
+			Tag:         "trimmed",
+			SchemaValue: schemaValue,
+		}
+	}
+	return true, nil
+}
+
+// substringRule 创建以 schema 值为子串参数的字符串规则
+func substringRule(tag string, verb string, match func(s, substr string) bool) RuleFunc {
+	return func(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+		str, ok := value.(string)
+		if !ok {
+			return false, &errors.ValidationError{Path: path, Message: "must be a string", Value: value, Tag: tag}
+		}
+		needle, ok := schemaValue.(string)
+		if !ok {
+			return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("%s must be a string", tag), Tag: tag}
+		}
+		if !match(str, needle) {
+			return false, &errors.ValidationError{
+				Path:        path,
+				Message:     fmt.Sprintf("value must %s '%s'", verb, needle),
+				Value:       value,
+				Tag:         tag,
+				Param:       needle,
+				SchemaValue: schemaValue,
+			}
+		}
+		return true, nil
+	}
+}
+
+// byteLengthRule 创建按 UTF-8 字节数限制字符串长度的规则，不受 byteLength 选项影响
+func byteLengthRule(tag string, relation string, within func(n, limit int) bool) RuleFunc {
+	return func(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+		str, ok := value.(string)
+		if !ok {
+			return false, &errors.ValidationError{Path: path, Message: "must be a string", Value: value, Tag: tag}
+		}
+		limit, ok := toInt(schemaValue)
+		if !ok || limit < 0 {
+			return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("%s must be a non-negative integer", tag), Tag: tag}
+		}
+		if n := len(str); !within(n, limit) {
+			return false, &errors.ValidationError{
+				Path:        path,
+				Message:     fmt.Sprintf("byte length %d %s %d", n, relation, limit),
+				Value:       value,
+				Tag:         tag,
+				Param:       fmt.Sprintf("%d", limit),
+				SchemaValue: schemaValue,
+			}
+		}
+		return true, nil
+	}
 }
 
 // validateMinLength 验证字符串最小长度
 func validateMinLength(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
-	if reflect.TypeOf(value).Kind() != reflect.String {
+	str, ok := value.(string)
+	if !ok {
 		return false, &errors.ValidationError{Path: path, Message: "must be a string", Tag: "minLength"}
 	}
-	str := value.(string)
 	min, ok := toInt(schemaValue)
 	if !ok || min < 0 {
 		return false, &errors.ValidationError{Path: path, Message: "minLength must be a non-negative integer", Tag: "minLength"}
 	}
-	if len(str) < min {
-		return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("length less than minimum %d", min), Tag: "minLength", Param: fmt.Sprintf("%d", min)}
+	if stringLength(ctx, str) < min {
+		return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("length less than minimum %d", min), Tag: "minLength", Param: fmt.Sprintf("%d", min), SchemaValue: schemaValue}
 	}
 	return true, nil
 }
 
 // validateMaxLength 验证字符串最大长度
 func validateMaxLength(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
-	if reflect.TypeOf(value).Kind() != reflect.String {
+	str, ok := value.(string)
+	if !ok {
 		return false, &errors.ValidationError{Path: path, Message: "must be a string", Tag: "maxLength"}
 	}
-	str := value.(string)
 	max, ok := toInt(schemaValue)
 	if !ok || max < 0 {
 		return false, &errors.ValidationError{Path: path, Message: "maxLength must be a non-negative integer", Tag: "maxLength"}
 	}
-	if len(str) > max {
-		return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("length greater than maximum %d", max), Tag: "maxLength", Param: fmt.Sprintf("%d", max)}
+	if stringLength(ctx, str) > max {
+		return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("length greater than maximum %d", max), Tag: "maxLength", Param: fmt.Sprintf("%d", max), SchemaValue: schemaValue}
 	}
 	return true, nil
 }
 
+// stringLength 返回字符串长度，默认按 Unicode 码点计数，上下文中 byteLength 为 true 时按字节计数
+func stringLength(ctx context.Context, str string) int {
+	if byteLength, _ := ctx.Value("byteLength").(bool); byteLength {
+		return len(str)
+	}
+	return utf8.RuneCountInString(str)
+}
+
+// regexCacheLimit 限制回退缓存中正则表达式的数量，超过后清空重建，避免按用户模式无限增长
+const regexCacheLimit = 1024
+
+// regexCache 是未经编译阶段（CompiledSchema.Regexps）覆盖的模式的回退缓存，*regexp.Regexp 可安全地并发使用
+var regexCache = struct {
+	sync.RWMutex
+	entries map[string]*regexp.Regexp
+}{entries: make(map[string]*regexp.Regexp)}
+
+// cachedRegexp 返回编译后的正则表达式，同一模式只编译一次；编译失败的模式不缓存
+func cachedRegexp(pattern string) (*regexp.Regexp, error) {
+	regexCache.RLock()
+	re, ok := regexCache.entries[pattern]
+	regexCache.RUnlock()
+	if ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	regexCache.Lock()
+	defer regexCache.Unlock()
+	if cached, ok := regexCache.entries[pattern]; ok {
+		return cached, nil
+	}
+	if len(regexCache.entries) >= regexCacheLimit {
+		regexCache.entries = make(map[string]*regexp.Regexp)
+	}
+	regexCache.entries[pattern] = re
+	return re, nil
+}
+
+// patternRegexp 优先使用上下文中编译阶段保存的正则表达式（regexps），没有时使用缓存
+func patternRegexp(ctx context.Context, pattern string) (*regexp.Regexp, error) {
+	if regexps, ok := ctx.Value("regexps").(map[string]*regexp.Regexp); ok {
+		if re, ok := regexps[pattern]; ok {
+			return re, nil
+		}
+	}
+	return cachedRegexp(pattern)
+}
+
 // validatePattern 验证字符串是否匹配正则表达式
 func validatePattern(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
-	if reflect.TypeOf(value).Kind() != reflect.String {
+	str, ok := value.(string)
+	if !ok {
 		return false, &errors.ValidationError{Path: path, Message: "must be a string", Tag: "pattern"}
 	}
-	str := value.(string)
 	pattern, ok := toString(schemaValue)
 	if !ok {
 		return false, &errors.ValidationError{Path: path, Message: "pattern must be a string", Tag: "pattern"}
 	}
-	re, err := regexp.Compile(pattern)
+	re, err := patternRegexp(ctx, pattern)
 	if err != nil {
 		return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("invalid pattern: %v", err), Tag: "pattern"}
 	}
 	if !re.MatchString(str) {
-		return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("does not match pattern %s", pattern), Tag: "pattern", Param: pattern}
+		return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("does not match pattern %s", pattern), Tag: "pattern", Param: pattern, SchemaValue: schemaValue}
 	}
 
 	return true, nil
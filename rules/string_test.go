@@ -2,6 +2,8 @@ package rules
 
 import (
 	"context"
+	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -23,7 +25,10 @@ func TestValidateMinLength(t *testing.T) {
 		{"Valid above min", "hello", 3, "root", true, ""},
 		{"Valid equal min", "abc", 3, "root", true, ""},
 		{"Invalid below min", "ab", 3, "root", false, "length less than minimum"},
+		{"Multibyte counted by rune", "café", 4, "root", true, ""},
+		{"Emoji counts as one", "👍", 2, "root", false, "length less than minimum"},
 		{"Invalid type", 123, 3, "root", false, "must be a string"},
+		{"Null value", nil, 3, "root", false, "must be a string"},
 		{"Invalid schema type", "hello", "not a number", "root", false, "minLength must be a non-negative integer"},
 	}
 
@@ -57,7 +62,10 @@ func TestValidateMaxLength(t *testing.T) {
 		{"Valid below max", "hi", 3, "root", true, ""},
 		{"Valid equal max", "abc", 3, "root", true, ""},
 		{"Invalid above max", "abcd", 3, "root", false, "length greater than maximum"},
+		{"Multibyte counted by rune", "café", 4, "root", true, ""},
+		{"Emoji counts as one", "👍", 1, "root", true, ""},
 		{"Invalid type", 123, 3, "root", false, "must be a string"},
+		{"Null value", nil, 3, "root", false, "must be a string"},
 	}
 
 	for _, tt := range tests {
@@ -90,6 +98,7 @@ func TestValidatePattern(t *testing.T) {
 		{"Valid match", "abc123", "^[a-z]+[0-9]+$", "root", true, ""},
 		{"Invalid no match", "123abc", "^[a-z]+[0-9]+$", "root", false, "does not match pattern"},
 		{"Invalid type", 123, "^[a-z]+$", "root", false, "must be a string"},
+		{"Null value", nil, "^[a-z]+$", "root", false, "must be a string"},
 		{"Invalid pattern", "abc", "[", "root", false, "invalid pattern"},
 	}
 
@@ -106,3 +115,193 @@ func TestValidatePattern(t *testing.T) {
 		})
 	}
 }
+
+func TestStringLengthByteMode(t *testing.T) {
+	ctx := context.WithValue(context.Background(), "byteLength", true)
+
+	valid, err := validateMaxLength(ctx, "café", 4, "root")
+	assert.False(t, valid)
+	assert.Error(t, err)
+
+	valid, err = validateMinLength(ctx, "👍", 4, "root")
+	assert.True(t, valid)
+	assert.NoError(t, err)
+}
+
+func TestSubstringRules(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name        string
+		tag         string
+		value       interface{}
+		schemaValue interface{}
+		expectValid bool
+		expectErr   string
+	}{
+		{"startswith valid", "startswith", "ADMIN_alice", "ADMIN_", true, ""},
+		{"startswith invalid", "startswith", "user", "ADMIN_", false, "value must start with 'ADMIN_'"},
+		{"endswith valid", "endswith", "data.json", ".json", true, ""},
+		{"endswith invalid", "endswith", "data.xml", ".json", false, "value must end with '.json'"},
+		{"containssub valid", "containssub", "a@b.c", "@", true, ""},
+		{"containssub invalid", "containssub", "abc", "@", false, "value must contain '@'"},
+		{"Non-string value", "startswith", 42, "ADMIN_", false, "must be a string"},
+		{"Invalid schema", "endswith", "abc", 1, false, "endswith must be a string"},
+	}
+
+	registry := NewRegistry()
+	registerStringRules(registry)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := registry.GetValidator(tt.tag)
+			assert.NotNil(t, rule)
+			valid, err := rule(ctx, tt.value, tt.schemaValue, "root")
+			assert.Equal(t, tt.expectValid, valid)
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestByteLengthRules(t *testing.T) {
+	ctx := context.Background()
+	registry := NewRegistry()
+	registerStringRules(registry)
+
+	// "héllo" 有 5 个字符、6 个字节，满足 maxLength=5 但不满足 maxBytes=5
+	valid, err := validateMaxLength(ctx, "héllo", 5, "root")
+	assert.True(t, valid)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		tag         string
+		value       interface{}
+		schemaValue interface{}
+		expectValid bool
+		expectErr   string
+	}{
+		{"maxBytes exceeded", "maxBytes", "héllo", 5, false, "byte length 6 greater than maximum 5"},
+		{"maxBytes within", "maxBytes", "héllo", 6, true, ""},
+		{"minBytes satisfied by multibyte", "minBytes", "日本", 6, true, ""},
+		{"minBytes not met", "minBytes", "ab", 3, false, "byte length 2 less than minimum 3"},
+		{"Non-string value", "minBytes", 12, 1, false, "must be a string"},
+		{"Invalid schema", "maxBytes", "ab", -1, false, "maxBytes must be a non-negative integer"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := registry.GetValidator(tt.tag)(ctx, tt.value, tt.schemaValue, "root")
+			assert.Equal(t, tt.expectValid, valid)
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestValidateTrimmed(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name        string
+		value       interface{}
+		schemaValue interface{}
+		expectValid bool
+		expectErr   string
+	}{
+		{"Trimmed", "x", true, true, ""},
+		{"Leading space", " x", true, false, "value must not have leading or trailing whitespace"},
+		{"Trailing space", "x ", true, false, "value must not have leading or trailing whitespace"},
+		{"Trailing newline", "x\n", true, false, "value must not have leading or trailing whitespace"},
+		{"Inner space", "x y", true, true, ""},
+		{"Empty string", "", true, true, ""},
+		{"Disabled", " x ", false, true, ""},
+		{"Non-string value", 42, true, false, "must be a string"},
+		{"Invalid schema", "x", []interface{}{}, false, "trimmed must be a boolean"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := validateTrimmed(ctx, tt.value, tt.schemaValue, "root")
+			assert.Equal(t, tt.expectValid, valid)
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestCachedRegexp(t *testing.T) {
+	re1, err := cachedRegexp(`^[a-z]+\d*$`)
+	assert.NoError(t, err)
+	re2, err := cachedRegexp(`^[a-z]+\d*$`)
+	assert.NoError(t, err)
+	assert.Same(t, re1, re2)
+
+	_, err = cachedRegexp(`(`)
+	assert.Error(t, err)
+	regexCache.RLock()
+	_, cached := regexCache.entries[`(`]
+	regexCache.RUnlock()
+	assert.False(t, cached)
+}
+
+func TestCachedRegexpBounded(t *testing.T) {
+	for i := 0; i < regexCacheLimit*2; i++ {
+		_, err := cachedRegexp(fmt.Sprintf(`^bounded-%d$`, i))
+		assert.NoError(t, err)
+	}
+	regexCache.RLock()
+	size := len(regexCache.entries)
+	regexCache.RUnlock()
+	assert.LessOrEqual(t, size, regexCacheLimit)
+}
+
+func BenchmarkValidatePattern(b *testing.B) {
+	const pattern = `^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`
+	ctx := context.Background()
+
+	b.Run("cached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < 10000; j++ {
+				validatePattern(ctx, "user@example.com", pattern, "root")
+			}
+		}
+	})
+	b.Run("uncached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < 10000; j++ {
+				re, err := regexp.Compile(pattern)
+				if err != nil {
+					b.Fatal(err)
+				}
+				re.MatchString("user@example.com")
+			}
+		}
+	})
+}
+
+func TestPatternRegexpFromContext(t *testing.T) {
+	precompiled := regexp.MustCompile(`^a+$`)
+	ctx := context.WithValue(context.Background(), "regexps", map[string]*regexp.Regexp{`^a+$`: precompiled})
+
+	re, err := patternRegexp(ctx, `^a+$`)
+	assert.NoError(t, err)
+	assert.Same(t, precompiled, re)
+
+	re, err = patternRegexp(ctx, `^b+$`)
+	assert.NoError(t, err)
+	assert.True(t, re.MatchString("bb"))
+
+	valid, err := validatePattern(ctx, "aaa", `^a+$`, "root")
+	assert.True(t, valid)
+	assert.NoError(t, err)
+}
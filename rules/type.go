@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 
 	"github.com/songzhibin97/jsonschema-validator/errors"
@@ -15,10 +17,29 @@ func registerTypeRules(registry ValidatorRegistry) {
 	registry.RegisterValidator("required", requiredValidator)
 	registry.RegisterValidator("minimum", minimumValidator)
 	registry.RegisterValidator("enum", enumValidator)
+	registry.RegisterValidator("nullable", validateNullable)
+}
+
+// validateNullable 仅校验 nullable 的取值，是否接受 null 由 type 规则读取上下文中的同级 nullable 决定
+func validateNullable(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	if _, ok := schemaValue.(bool); !ok {
+		return false, &errors.ValidationError{
+			Path:    path,
+			Message: "nullable must be a boolean",
+			Value:   schemaValue,
+			Tag:     "nullable",
+		}
+	}
+	return true, nil
 }
 
 // validateType 验证值的类型
 func validateType(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+	// 同级 nullable 为 true 时，null 满足任意声明的类型
+	if nullable, _ := ctx.Value("nullable").(bool); nullable && value == nil {
+		return true, nil
+	}
+
 	// 处理多类型情况（type: ["string", "number"]）
 	if types, ok := schemaValue.([]interface{}); ok {
 		for _, t := range types {
@@ -26,7 +47,7 @@ func validateType(ctx context.Context, value interface{}, schemaValue interface{
 			if !ok {
 				continue
 			}
-			if checkType(value, typeStr) {
+			if checkTypeCoerced(ctx, value, typeStr) {
 				return true, nil
 			}
 		}
@@ -57,7 +78,7 @@ func validateType(ctx context.Context, value interface{}, schemaValue interface{
 		}
 	}
 
-	if !checkType(value, typeStr) {
+	if !checkTypeCoerced(ctx, value, typeStr) {
 		return false, &errors.ValidationError{
 			Path:    path,
 			Message: fmt.Sprintf("value is of type %T, expected %s", value, typeStr),
@@ -70,6 +91,48 @@ func validateType(ctx context.Context, value interface{}, schemaValue interface{
 	return true, nil
 }
 
+// checkTypeCoerced 检查值是否符合指定类型；上下文开启 coerceTypes 时，
+// 字符串形式的整数、数字和布尔值（例如查询参数中的 "30"、"true"）也视为对应类型
+func checkTypeCoerced(ctx context.Context, value interface{}, typeName string) bool {
+	// 上下文开启 strictTypes 时，json.Number 形式的整数不能带小数点或指数，例如 30.0 不是整数
+	if n, ok := value.(json.Number); ok && typeName == "integer" {
+		if strict, _ := ctx.Value("strictTypes").(bool); strict && strings.ContainsAny(n.String(), ".eE") {
+			return false
+		}
+	}
+	if checkType(value, typeName) {
+		return true
+	}
+	// numberParser 解码出的自定义数值类型满足 number；字面形式不含小数点和指数时也满足 integer
+	if isCustomNumber(ctx, value) {
+		switch typeName {
+		case "number":
+			return true
+		case "integer":
+			return !strings.ContainsAny(fmt.Sprint(value), ".eE")
+		}
+	}
+	coerce, _ := ctx.Value("coerceTypes").(bool)
+	str, isString := value.(string)
+	if !coerce || !isString {
+		return false
+	}
+	switch typeName {
+	case "integer":
+		_, ok := toInt(str)
+		return ok
+	case "number":
+		// 整个字符串必须是有限的十进制数，拒绝 "30abc" 这类尾部多余字符以及 NaN、Inf
+		f, err := strconv.ParseFloat(str, 64)
+		return err == nil && !math.IsNaN(f) && !math.IsInf(f, 0)
+	case "boolean":
+		_, err := strconv.ParseBool(str)
+		return err == nil
+	default:
+		return false
+	}
+}
+
 // checkType 检查值是否符合指定的类型
 func checkType(value interface{}, typeName string) bool {
 	if value == nil {
@@ -3,8 +3,11 @@ package rules
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"testing"
 
+	"github.com/songzhibin97/jsonschema-validator/errors"
+	"github.com/songzhibin97/jsonschema-validator/schema"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -89,6 +92,26 @@ func TestValidateType(t *testing.T) {
 	}
 }
 
+func TestValidateTypeNullable(t *testing.T) {
+	ctx := context.Background()
+	nullableCtx := context.WithValue(ctx, "nullable", true)
+
+	valid, err := validateType(nullableCtx, nil, "string", "root")
+	assert.True(t, valid)
+	assert.NoError(t, err)
+
+	valid, err = validateType(ctx, nil, "string", "root")
+	assert.False(t, valid)
+	assert.Error(t, err)
+
+	valid, err = validateType(nullableCtx, 42, "string", "root")
+	assert.False(t, valid)
+	assert.Error(t, err)
+
+	_, err = validateNullable(ctx, nil, "yes", "root")
+	assert.ErrorContains(t, err, "nullable must be a boolean")
+}
+
 func TestCheckType(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -118,3 +141,129 @@ func TestCheckType(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckTypeCoerced(t *testing.T) {
+	coerce := context.WithValue(context.Background(), "coerceTypes", true)
+	tests := []struct {
+		name     string
+		value    interface{}
+		typeName string
+		expected bool
+	}{
+		{"Integer string", "30", "integer", true},
+		{"Decimal string as integer", "30.5", "integer", false},
+		{"Number string", "30.5", "number", true},
+		{"Boolean string", "true", "boolean", true},
+		{"Non-boolean string", "yes please", "boolean", false},
+		{"Non-numeric string", "abc", "number", false},
+		{"Exponent string", "3e1", "number", true},
+		{"Trailing garbage", "30abc", "number", false},
+		{"Trailing space", "30 ", "number", false},
+		{"NaN", "NaN", "number", false},
+		{"Inf", "inf", "number", false},
+		{"Signed infinity", "-Infinity", "number", false},
+		{"Trailing garbage as integer", "30abc", "integer", false},
+		{"String stays string", "30", "string", true},
+		{"No coercion to object", "{}", "object", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, checkTypeCoerced(coerce, tt.value, tt.typeName))
+		})
+	}
+
+	// 未开启时不做转换
+	assert.False(t, checkTypeCoerced(context.Background(), "30", "integer"))
+}
+
+func TestEnumValidatorSchemaValue(t *testing.T) {
+	allowed := []string{"admin", "user"}
+
+	valid, err := enumValidator(context.Background(), "admin", allowed, "root")
+	assert.True(t, valid)
+	assert.NoError(t, err)
+
+	valid, err = enumValidator(context.Background(), "guest", allowed, "root")
+	assert.False(t, valid)
+	ve, ok := err.(*errors.ValidationError)
+	if assert.True(t, ok) {
+		assert.Equal(t, "enum", ve.Tag)
+		assert.Equal(t, allowed, ve.SchemaValue)
+		assert.Equal(t, "guest", ve.Value)
+	}
+}
+
+// compiledEnum 通过 schema.Compile 构建编译后的枚举
+func compiledEnum(t testing.TB, values []interface{}) interface{} {
+	s := &schema.Schema{Raw: map[string]interface{}{"enum": values}}
+	if err := s.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	return s.Compiled.Keywords["enum"]
+}
+
+// largeEnum 生成 n 个字符串成员，并混入数值、null 和对象成员
+func largeEnum(n int) []interface{} {
+	values := make([]interface{}, 0, n+3)
+	for i := 0; i < n; i++ {
+		values = append(values, fmt.Sprintf("code-%03d", i))
+	}
+	return append(values, float64(1), nil, map[string]interface{}{"a": "b"})
+}
+
+func TestCompiledEnumMatchesLinear(t *testing.T) {
+	values := largeEnum(500)
+	compiled := compiledEnum(t, values)
+	assert.IsType(t, &schema.EnumSet{}, compiled)
+
+	inputs := []interface{}{
+		"code-000", "code-499", "code-250", "code-500", "CODE-001", "",
+		float64(1), json.Number("1"), 1, float64(2), nil, true,
+		map[string]interface{}{"a": "b"}, map[string]interface{}{"a": "c"},
+	}
+	for _, input := range inputs {
+		t.Run(fmt.Sprintf("%v", input), func(t *testing.T) {
+			expectValid, expectErr := enumValidator(context.Background(), input, values, "root")
+			valid, err := enumValidator(context.Background(), input, compiled, "root")
+			assert.Equal(t, expectValid, valid)
+			assert.Equal(t, expectErr, err)
+		})
+	}
+}
+
+func BenchmarkEnumValidator(b *testing.B) {
+	values := largeEnum(500)
+	compiled := compiledEnum(b, values)
+	ctx := context.Background()
+
+	b.Run("compiled", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			enumValidator(ctx, "code-499", compiled, "root")
+		}
+	})
+	b.Run("linear", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			enumValidator(ctx, "code-499", values, "root")
+		}
+	})
+}
+
+func TestConstraintSchemaValue(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := validateMaxLength(ctx, "abcdef", 3, "root")
+	if ve, ok := err.(*errors.ValidationError); assert.True(t, ok) {
+		assert.Equal(t, 3, ve.SchemaValue)
+	}
+
+	_, err = validateMaximum(ctx, 10.0, 5.5, "root")
+	if ve, ok := err.(*errors.ValidationError); assert.True(t, ok) {
+		assert.Equal(t, 5.5, ve.SchemaValue)
+	}
+
+	_, err = validateMinItems(ctx, []interface{}{}, 1, "root")
+	if ve, ok := err.(*errors.ValidationError); assert.True(t, ok) {
+		assert.Equal(t, 1, ve.SchemaValue)
+	}
+}
@@ -209,6 +209,50 @@ func validateUUID(str string) bool {
 	return pattern.MatchString(strings.ToLower(str))
 }
 
+// semverPattern 是语义化版本 2.0.0 的官方正则表达式
+var semverPattern = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// validateSemver 验证语义化版本格式（major.minor.patch，可选预发布和构建元数据）
+func validateSemver(str string) bool {
+	return semverPattern.MatchString(str)
+}
+
+// bcp47Pattern 按 RFC 5646 语法匹配语言标签：language(-extlang)(-script)(-region)(-variant)(-extension)(-privateuse)，
+// 或仅含私有用途子标签；language 只接受常用的 2-3 个字母形式，因此 "english" 这类单词会被拒绝
+var bcp47Pattern = regexp.MustCompile(`^(?i:[a-z]{2,3}(?:-[a-z]{3}){0,3}(?:-[a-z]{4})?(?:-(?:[a-z]{2}|\d{3}))?(?:-(?:[a-z\d]{5,8}|\d[a-z\d]{3}))*(?:-[\da-wy-z](?:-[a-z\d]{2,8})+)*(?:-x(?:-[a-z\d]{1,8})+)?|x(?:-[a-z\d]{1,8})+)$`)
+
+// validateBCP47 验证 BCP 47 语言标签，例如 "en-US"、"zh-Hant-TW"
+func validateBCP47(str string) bool {
+	return bcp47Pattern.MatchString(str)
+}
+
+// validateCreditCard 验证信用卡号（忽略空格和连字符，长度13-19位并满足Luhn校验）
+func validateCreditCard(str string) bool {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(str)
+	if len(digits) < 13 || len(digits) > 19 {
+		return false
+	}
+
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		c := digits[i]
+		if c < '0' || c > '9' {
+			return false
+		}
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
 // 集合操作函数
 
 // Contains 检查数组是否包含指定元素
@@ -221,11 +265,118 @@ func Contains(arr []interface{}, val interface{}) bool {
 	return false
 }
 
+// deepEqualJSON 按JSON语义深度比较两个值，数值统一转换为float64后比较（兼容 json.Number、整数和浮点数）
+func deepEqualJSON(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	if fa, ok := toNumber(a); ok {
+		fb, ok := toNumber(b)
+		return ok && fa == fb
+	}
+
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for k, v := range av {
+			other, exists := bv[k]
+			if !exists || !deepEqualJSON(v, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !deepEqualJSON(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// toNumber 仅对数值类型做转换，不解析字符串
+func toNumber(value interface{}) (float64, bool) {
+	if _, ok := value.(string); ok {
+		return 0, false
+	}
+	return toFloat64(value)
+}
+
+// setLookupThreshold 是使用哈希查找的最小数组长度，更短的数组直接线性比较更快
+const setLookupThreshold = 8
+
+// valueSet 按 reflect.DeepEqual 语义判断元素是否存在：字符串、数值、布尔等可比较的标量用 map 查找，
+// 对象、数组等复杂值退回线性 DeepEqual 比较
+type valueSet struct {
+	scalars map[interface{}]struct{}
+	complex []interface{}
+}
+
+// newValueSet 使用数组中的元素创建 valueSet
+func newValueSet(arr []interface{}) *valueSet {
+	set := &valueSet{scalars: make(map[interface{}]struct{}, len(arr))}
+	for _, item := range arr {
+		set.add(item)
+	}
+	return set
+}
+
+// add 向集合中添加元素
+func (s *valueSet) add(item interface{}) {
+	if isHashableScalar(item) {
+		s.scalars[item] = struct{}{}
+		return
+	}
+	s.complex = append(s.complex, item)
+}
+
+// contains 检查集合中是否存在与 item 深度相等的元素
+func (s *valueSet) contains(item interface{}) bool {
+	if isHashableScalar(item) {
+		// 标量只可能与同类型的标量相等，而它们都在 scalars 中
+		_, ok := s.scalars[item]
+		return ok
+	}
+	return Contains(s.complex, item)
+}
+
+// isHashableScalar 检查值能否作为 map 键，且 == 比较结果与 reflect.DeepEqual 一致
+func isHashableScalar(v interface{}) bool {
+	switch v.(type) {
+	case nil, string, bool, json.Number,
+		int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64,
+		float32, float64:
+		return true
+	}
+	return false
+}
+
+// containsFunc 返回检查元素是否在 arr 中的函数，数组较大时使用哈希查找
+func containsFunc(arr []interface{}) func(interface{}) bool {
+	if len(arr) < setLookupThreshold {
+		return func(item interface{}) bool { return Contains(arr, item) }
+	}
+	return newValueSet(arr).contains
+}
+
 // Intersection 计算两个数组的交集
 func Intersection(a, b []interface{}) []interface{} {
 	result := make([]interface{}, 0)
+	inB := containsFunc(b)
 	for _, item := range a {
-		if Contains(b, item) {
+		if inB(item) {
 			result = append(result, item)
 		}
 	}
@@ -234,12 +385,23 @@ func Intersection(a, b []interface{}) []interface{} {
 
 // Union 计算两个数组的并集
 func Union(a, b []interface{}) []interface{} {
-	result := make([]interface{}, len(a))
+	result := make([]interface{}, len(a), len(a)+len(b))
 	copy(result, a)
 
+	if len(a)+len(b) < setLookupThreshold {
+		for _, item := range b {
+			if !Contains(result, item) {
+				result = append(result, item)
+			}
+		}
+		return result
+	}
+
+	seen := newValueSet(a)
 	for _, item := range b {
-		if !Contains(result, item) {
+		if !seen.contains(item) {
 			result = append(result, item)
+			seen.add(item)
 		}
 	}
 	return result
@@ -248,8 +410,9 @@ func Union(a, b []interface{}) []interface{} {
 // Difference 计算两个数组的差集（a - b）
 func Difference(a, b []interface{}) []interface{} {
 	result := make([]interface{}, 0)
+	inB := containsFunc(b)
 	for _, item := range a {
-		if !Contains(b, item) {
+		if !inB(item) {
 			result = append(result, item)
 		}
 	}
@@ -1,6 +1,7 @@
 package rules
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"testing"
@@ -142,6 +143,17 @@ func TestFormatValidators(t *testing.T) {
 		{"IPv6 invalid", validateIPv6, "2001::db8::1", false},
 		{"UUID valid", validateUUID, "123e4567-e89b-12d3-a456-426614174000", true},
 		{"UUID invalid", validateUUID, "invalid-uuid", false},
+		{"CreditCard valid", validateCreditCard, "4111111111111111", true},
+		{"CreditCard valid with separators", validateCreditCard, "4111 1111-1111 1111", true},
+		{"CreditCard invalid checksum", validateCreditCard, "4111111111111112", false},
+		{"CreditCard non-numeric", validateCreditCard, "4111-abcd-1111-1111", false},
+		{"CreditCard too short", validateCreditCard, "4111111", false},
+		{"Semver valid", validateSemver, "1.2.3", true},
+		{"Semver valid prerelease and build", validateSemver, "1.0.0-alpha.1+build", true},
+		{"Semver invalid missing patch", validateSemver, "1.2", false},
+		{"Semver invalid prefix", validateSemver, "v1.2.3", false},
+		{"Semver invalid leading zero", validateSemver, "01.2.3", false},
+		{"Semver invalid empty prerelease", validateSemver, "1.2.3-", false},
 	}
 
 	for _, tt := range tests {
@@ -151,3 +163,182 @@ func TestFormatValidators(t *testing.T) {
 		})
 	}
 }
+
+func TestDeepEqualJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        interface{}
+		b        interface{}
+		expected bool
+	}{
+		{"json.Number and float64", json.Number("1"), float64(1), true},
+		{"json.Number and int", json.Number("2.5"), 2.5, true},
+		{"int and float64", 3, 3.0, true},
+		{"Different numbers", json.Number("1"), 1.5, false},
+		{"Number and string", json.Number("1"), "1", false},
+		{"Nested mixed numbers", map[string]interface{}{"a": []interface{}{json.Number("1"), 2}}, map[string]interface{}{"a": []interface{}{1.0, json.Number("2")}}, true},
+		{"Nested mismatch", map[string]interface{}{"a": []interface{}{json.Number("1")}}, map[string]interface{}{"a": []interface{}{2.0}}, false},
+		{"Nil values", nil, nil, true},
+		{"Nil and value", nil, 0, false},
+		{"Booleans", true, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, deepEqualJSON(tt.a, tt.b))
+			assert.Equal(t, tt.expected, deepEqualJSON(tt.b, tt.a))
+		})
+	}
+}
+
+func TestNumericEqualityInRules(t *testing.T) {
+	ctx := context.Background()
+
+	valid, err := validateConst(ctx, json.Number("10"), 10.0, "root")
+	assert.True(t, valid)
+	assert.NoError(t, err)
+
+	valid, err = enumValidator(ctx, json.Number("2"), []interface{}{1.0, 2.0, "x"}, "root")
+	assert.True(t, valid)
+	assert.NoError(t, err)
+
+	valid, err = enumValidator(ctx, json.Number("3"), []interface{}{1.0, 2.0, "x"}, "root")
+	assert.False(t, valid)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "value must be one of: 1, 2, x")
+
+	valid, err = validateUniqueItems(ctx, []interface{}{json.Number("1"), 1.0}, true, "root")
+	assert.False(t, valid)
+	assert.Error(t, err)
+}
+
+// 以下为哈希优化前的线性实现，用于校验结果一致
+func naiveIntersection(a, b []interface{}) []interface{} {
+	result := make([]interface{}, 0)
+	for _, item := range a {
+		if Contains(b, item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func naiveUnion(a, b []interface{}) []interface{} {
+	result := make([]interface{}, len(a))
+	copy(result, a)
+	for _, item := range b {
+		if !Contains(result, item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func naiveDifference(a, b []interface{}) []interface{} {
+	result := make([]interface{}, 0)
+	for _, item := range a {
+		if !Contains(b, item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// mixedValues 生成包含字符串、各类数值、布尔、nil、对象和数组的测试数据
+func mixedValues(n, offset int) []interface{} {
+	values := make([]interface{}, 0, n)
+	for i := 0; i < n; i++ {
+		k := i + offset
+		switch i % 8 {
+		case 0:
+			values = append(values, fmt.Sprintf("s%d", k%50))
+		case 1:
+			values = append(values, float64(k%40))
+		case 2:
+			values = append(values, k%40)
+		case 3:
+			values = append(values, json.Number(fmt.Sprintf("%d", k%30)))
+		case 4:
+			values = append(values, k%2 == 0)
+		case 5:
+			values = append(values, nil)
+		case 6:
+			values = append(values, map[string]interface{}{"id": float64(k % 10)})
+		case 7:
+			values = append(values, []interface{}{float64(k % 5), "x"})
+		}
+	}
+	return values
+}
+
+func TestSetOperationsMatchLinear(t *testing.T) {
+	sizes := [][2]int{{0, 0}, {3, 2}, {5, 20}, {40, 7}, {200, 300}}
+	for _, size := range sizes {
+		a := mixedValues(size[0], 0)
+		b := mixedValues(size[1], 3)
+		name := fmt.Sprintf("%dx%d", size[0], size[1])
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, naiveIntersection(a, b), Intersection(a, b))
+			assert.Equal(t, naiveUnion(a, b), Union(a, b))
+			assert.Equal(t, naiveDifference(a, b), Difference(a, b))
+		})
+	}
+
+	// DeepEqual 语义：不同数值类型不相等
+	a := []interface{}{1, float64(1), "1", json.Number("1")}
+	b := append(mixedValues(20, 100), float64(1))
+	assert.Equal(t, []interface{}{float64(1)}, Intersection(a, b))
+	assert.Equal(t, []interface{}{1, "1", json.Number("1")}, Difference(a, b))
+}
+
+func benchmarkSetData(n int) ([]interface{}, []interface{}) {
+	a := make([]interface{}, n)
+	b := make([]interface{}, n)
+	for i := 0; i < n; i++ {
+		a[i] = fmt.Sprintf("item-%d", i)
+		b[i] = fmt.Sprintf("item-%d", i*2)
+	}
+	return a, b
+}
+
+func BenchmarkIntersection(b *testing.B) {
+	x, y := benchmarkSetData(2000)
+	b.Run("hashed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Intersection(x, y)
+		}
+	})
+	b.Run("linear", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			naiveIntersection(x, y)
+		}
+	})
+}
+
+func BenchmarkDifference(b *testing.B) {
+	x, y := benchmarkSetData(2000)
+	b.Run("hashed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Difference(x, y)
+		}
+	})
+	b.Run("linear", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			naiveDifference(x, y)
+		}
+	})
+}
+
+func BenchmarkUnion(b *testing.B) {
+	x, y := benchmarkSetData(2000)
+	b.Run("hashed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Union(x, y)
+		}
+	})
+	b.Run("linear", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			naiveUnion(x, y)
+		}
+	})
+}
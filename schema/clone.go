@@ -0,0 +1,125 @@
+package schema
+
+import "regexp"
+
+// Clone 返回Schema的深拷贝：Raw 中嵌套的对象和数组以及编译结果都会复制，
+// 修改副本（例如注入默认值）不会影响原Schema或验证器缓存中共享的实例。
+// 编译后的正则表达式不可变，副本与原Schema共享
+func (s *Schema) Clone() *Schema {
+	if s == nil {
+		return nil
+	}
+	clone := *s
+	if s.Raw != nil {
+		clone.Raw = cloneJSONValue(s.Raw).(map[string]interface{})
+	}
+	clone.Compiled = s.Compiled.clone(make(map[*CompiledSchema]*CompiledSchema))
+	return &clone
+}
+
+// clone 深拷贝编译结果，seen 记录已复制的子schema，使同一子schema的多处引用在副本中仍指向同一对象
+func (c *CompiledSchema) clone(seen map[*CompiledSchema]*CompiledSchema) *CompiledSchema {
+	if c == nil {
+		return nil
+	}
+	if cloned, ok := seen[c]; ok {
+		return cloned
+	}
+	clone := &CompiledSchema{}
+	seen[c] = clone
+
+	if c.Keywords != nil {
+		clone.Keywords = make(map[string]interface{}, len(c.Keywords))
+		for key, value := range c.Keywords {
+			clone.Keywords[key] = cloneCompiledValue(value, seen)
+		}
+	}
+	if c.TypeRules != nil {
+		clone.TypeRules = make(map[string][]string, len(c.TypeRules))
+		for key, rules := range c.TypeRules {
+			clone.TypeRules[key] = append([]string(nil), rules...)
+		}
+	}
+	if c.SubSchemas != nil {
+		clone.SubSchemas = make(map[string]*CompiledSchema, len(c.SubSchemas))
+		for key, sub := range c.SubSchemas {
+			clone.SubSchemas[key] = sub.clone(seen)
+		}
+	}
+	if c.Regexps != nil {
+		clone.Regexps = make(map[string]*regexp.Regexp, len(c.Regexps))
+		for pattern, re := range c.Regexps {
+			clone.Regexps[pattern] = re
+		}
+	}
+	if c.Order != nil {
+		clone.Order = append([]string(nil), c.Order...)
+	}
+	if c.Boolean != nil {
+		b := *c.Boolean
+		clone.Boolean = &b
+	}
+	return clone
+}
+
+// cloneCompiledValue 深拷贝编译后关键字的取值
+func cloneCompiledValue(value interface{}, seen map[*CompiledSchema]*CompiledSchema) interface{} {
+	switch v := value.(type) {
+	case *CompiledSchema:
+		return v.clone(seen)
+	case map[string]*CompiledSchema:
+		schemas := make(map[string]*CompiledSchema, len(v))
+		for name, sub := range v {
+			schemas[name] = sub.clone(seen)
+		}
+		return schemas
+	case []*CompiledSchema:
+		schemas := make([]*CompiledSchema, len(v))
+		for i, sub := range v {
+			schemas[i] = sub.clone(seen)
+		}
+		return schemas
+	case *EnumSet:
+		return newEnumSet(cloneJSONValue(v.values).([]interface{}))
+	case []string:
+		return append([]string(nil), v...)
+	case map[string]interface{}:
+		// dependencies 的取值混合了 []string 和 *CompiledSchema，其余为原样保存的关键字
+		obj := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			obj[key] = cloneCompiledValue(item, seen)
+		}
+		return obj
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, item := range v {
+			list[i] = cloneCompiledValue(item, seen)
+		}
+		return list
+	default:
+		return value
+	}
+}
+
+// cloneJSONValue 深拷贝解码后的JSON值，对象和数组逐层复制，其余值不可变可直接共享
+func cloneJSONValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		obj := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			obj[key] = cloneJSONValue(item)
+		}
+		return obj
+	case []interface{}:
+		if v == nil {
+			return v
+		}
+		list := make([]interface{}, len(v))
+		for i, item := range v {
+			list[i] = cloneJSONValue(item)
+		}
+		return list
+	default:
+		return value
+	}
+}
@@ -0,0 +1,76 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClone(t *testing.T) {
+	schemaJSON := `{
+		"type": "object",
+		"required": ["name"],
+		"properties": {
+			"name": {"type": "string", "minLength": 1, "pattern": "^[a-z]+$"},
+			"role": {"enum": ["admin", "user"]},
+			"tags": {"type": "array", "items": {"type": "string"}}
+		},
+		"dependencies": {"name": ["role"]},
+		"uniqueBy": {"property": "id"}
+	}`
+	original, err := Parse(schemaJSON)
+	assert.NoError(t, err)
+	assert.NoError(t, original.Compile())
+	before := original.String()
+
+	clone := original.Clone()
+	assert.Equal(t, before, clone.String())
+	assert.Equal(t, original.Compiled.Order, clone.Compiled.Order)
+
+	// 修改副本的原始数据
+	props := clone.Raw["properties"].(map[string]interface{})
+	props["name"].(map[string]interface{})["minLength"] = float64(5)
+	props["extra"] = map[string]interface{}{"type": "boolean"}
+	clone.Raw["required"].([]interface{})[0] = "extra"
+	clone.Raw["uniqueBy"].(map[string]interface{})["property"] = "email"
+
+	// 修改副本的编译结果
+	compiledProps := clone.Compiled.Keywords["properties"].(map[string]*CompiledSchema)
+	compiledProps["name"].Keywords["minLength"] = 5
+	compiledProps["name"].Regexps["^[a-z]+$"] = nil
+	compiledProps["tags"].Keywords["items"].(*CompiledSchema).Keywords["type"] = "integer"
+	compiledProps["role"].Keywords["enum"].(*EnumSet).values[0] = "root"
+	delete(compiledProps, "role")
+	clone.Compiled.Keywords["required"].([]string)[0] = "extra"
+	clone.Compiled.Keywords["dependencies"].(map[string]interface{})["name"].([]string)[0] = "extra"
+	clone.Compiled.Keywords["uniqueBy"].(map[string]interface{})["property"] = "email"
+	clone.Compiled.Order[0] = "required"
+
+	assert.Equal(t, before, original.String())
+	origProps := original.Compiled.Keywords["properties"].(map[string]*CompiledSchema)
+	assert.Equal(t, 1, origProps["name"].Keywords["minLength"])
+	assert.NotNil(t, origProps["name"].Regexps["^[a-z]+$"])
+	assert.Equal(t, "string", origProps["tags"].Keywords["items"].(*CompiledSchema).Keywords["type"])
+	if assert.Contains(t, origProps, "role") {
+		assert.True(t, origProps["role"].Keywords["enum"].(*EnumSet).HasString("admin"))
+		assert.Equal(t, []interface{}{"admin", "user"}, origProps["role"].Keywords["enum"].(*EnumSet).Values())
+	}
+	assert.Equal(t, []string{"name"}, original.Compiled.Keywords["required"])
+	assert.Equal(t, []string{"role"}, original.Compiled.Keywords["dependencies"].(map[string]interface{})["name"])
+	assert.Equal(t, "id", original.Compiled.Keywords["uniqueBy"].(map[string]interface{})["property"])
+	assert.Equal(t, "type", original.Compiled.Order[0])
+
+	// 布尔schema和未编译的schema
+	b, err := Parse(`{"properties": {"none": false}}`)
+	assert.NoError(t, err)
+	assert.NoError(t, b.Compile())
+	noneClone := b.Clone().Compiled.Keywords["properties"].(map[string]*CompiledSchema)["none"]
+	*noneClone.Boolean = true
+	assert.False(t, *b.Compiled.Keywords["properties"].(map[string]*CompiledSchema)["none"].Boolean)
+
+	raw := &Schema{Raw: map[string]interface{}{"type": "string"}, Mode: ModeLoose}
+	rawClone := raw.Clone()
+	assert.Nil(t, rawClone.Compiled)
+	assert.Equal(t, ModeLoose, rawClone.Mode)
+	assert.Nil(t, (*Schema)(nil).Clone())
+}
@@ -0,0 +1,252 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/songzhibin97/jsonschema-validator/errors"
+)
+
+// validTypeNames 是 type 关键字允许的类型名称
+var validTypeNames = map[string]bool{
+	"null":    true,
+	"boolean": true,
+	"object":  true,
+	"array":   true,
+	"number":  true,
+	"string":  true,
+	"integer": true,
+}
+
+// ValidateSchema 检查schema本身是否为结构正确的 JSON Schema，覆盖 Compile 不做的检查，
+// 例如 type 名称是否合法、required 是否为不重复的字符串数组、enum 成员是否重复；
+// 根schema与子schema一样可以是对象或布尔值；
+// 所有问题以 errors.ValidationErrors 返回，Path 为问题关键字在schema中的 JSON Pointer
+func ValidateSchema(schemaJSON string) error {
+	var raw interface{}
+	if err := json.Unmarshal([]byte(schemaJSON), &raw); err != nil {
+		return fmt.Errorf("failed to parse schema: %w", err)
+	}
+	var errs errors.ValidationErrors
+	checkSchemaNode(raw, "", &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// checkSchemaNode 检查单个（子）schema，布尔schema总是合法
+func checkSchemaNode(node interface{}, path string, errs *errors.ValidationErrors) {
+	if _, ok := node.(bool); ok {
+		return
+	}
+	obj, ok := node.(map[string]interface{})
+	if !ok {
+		addSchemaError(errs, path, "", fmt.Sprintf("schema must be an object or a boolean, got %s", jsonTypeName(node)))
+		return
+	}
+
+	for _, key := range sortedKeys(obj) {
+		checkSchemaKeyword(key, obj[key], path+"/"+escapePointerToken(key), errs)
+	}
+}
+
+// checkSchemaKeyword 按关键字检查取值的结构，未知关键字不做检查
+func checkSchemaKeyword(key string, value interface{}, path string, errs *errors.ValidationErrors) {
+	switch key {
+	case "type":
+		checkTypeKeyword(value, path, errs)
+	case "required":
+		checkStringArray(key, value, path, errs)
+	case "enum":
+		values, ok := value.([]interface{})
+		if !ok {
+			addSchemaError(errs, path, key, fmt.Sprintf("enum must be an array, got %s", jsonTypeName(value)))
+		} else if first, dup, found := duplicateEnumMember(values); found {
+			addSchemaError(errs, fmt.Sprintf("%s/%d", path, dup), key, fmt.Sprintf("enum items must be unique: %s duplicates index %d", enumValueString(values[dup]), first))
+		}
+	case "minimum", "maximum":
+		if _, ok := value.(float64); !ok {
+			addSchemaError(errs, path, key, fmt.Sprintf("%s must be a number, got %s", key, jsonTypeName(value)))
+		}
+	case "exclusiveMinimum", "exclusiveMaximum":
+		switch value.(type) {
+		case float64, bool:
+		default:
+			addSchemaError(errs, path, key, fmt.Sprintf("%s must be a number or a boolean, got %s", key, jsonTypeName(value)))
+		}
+	case "multipleOf":
+		if n, ok := value.(float64); !ok || n <= 0 {
+			addSchemaError(errs, path, key, fmt.Sprintf("multipleOf must be a number greater than 0, got %v", value))
+		}
+	case "minLength", "maxLength", "minItems", "maxItems", "minProperties", "maxProperties", "minBytes", "maxBytes":
+		if n, ok := value.(float64); !ok || n < 0 || n != math.Trunc(n) {
+			addSchemaError(errs, path, key, fmt.Sprintf("%s must be a non-negative integer, got %v", key, value))
+		}
+	case "uniqueItems", "nullable":
+		if _, ok := value.(bool); !ok {
+			addSchemaError(errs, path, key, fmt.Sprintf("%s must be a boolean, got %s", key, jsonTypeName(value)))
+		}
+	case "format":
+		if _, ok := value.(string); !ok {
+			addSchemaError(errs, path, key, fmt.Sprintf("format must be a string, got %s", jsonTypeName(value)))
+		}
+	case "pattern":
+		str, ok := value.(string)
+		if !ok {
+			addSchemaError(errs, path, key, fmt.Sprintf("pattern must be a string, got %s", jsonTypeName(value)))
+		} else if _, err := regexp.Compile(str); err != nil {
+			addSchemaError(errs, path, key, fmt.Sprintf("invalid pattern: %s - %v", str, err))
+		}
+	case "properties", "$defs", "definitions":
+		checkSchemaMap(key, value, path, errs)
+	case "patternProperties":
+		if patterns, ok := value.(map[string]interface{}); ok {
+			for _, pattern := range sortedKeys(patterns) {
+				if _, err := regexp.Compile(pattern); err != nil {
+					addSchemaError(errs, path+"/"+escapePointerToken(pattern), key, fmt.Sprintf("invalid pattern: %s - %v", pattern, err))
+				}
+			}
+		}
+		checkSchemaMap(key, value, path, errs)
+	case "items":
+		if list, ok := value.([]interface{}); ok {
+			checkSchemaList(list, path, errs)
+		} else {
+			checkSchemaNode(value, path, errs)
+		}
+	case "additionalItems", "additionalProperties", "not", "if", "then", "else", "contains", "propertyNames":
+		checkSchemaNode(value, path, errs)
+	case "allOf", "anyOf", "oneOf", "prefixItems":
+		list, ok := value.([]interface{})
+		if !ok || len(list) == 0 {
+			addSchemaError(errs, path, key, fmt.Sprintf("%s must be a non-empty array of schemas", key))
+			return
+		}
+		checkSchemaList(list, path, errs)
+	case "dependencies":
+		deps, ok := value.(map[string]interface{})
+		if !ok {
+			addSchemaError(errs, path, key, fmt.Sprintf("dependencies must be an object, got %s", jsonTypeName(value)))
+			return
+		}
+		for _, name := range sortedKeys(deps) {
+			dep := deps[name]
+			depPath := path + "/" + escapePointerToken(name)
+			if _, ok := dep.([]interface{}); ok {
+				checkStringArray(key, dep, depPath, errs)
+			} else {
+				checkSchemaNode(dep, depPath, errs)
+			}
+		}
+	}
+}
+
+// checkTypeKeyword 检查 type 是合法的类型名称，或由不重复的合法类型名称组成的数组
+func checkTypeKeyword(value interface{}, path string, errs *errors.ValidationErrors) {
+	switch v := value.(type) {
+	case string:
+		if !validTypeNames[v] {
+			addSchemaError(errs, path, "type", fmt.Sprintf("invalid type name '%s'", v))
+		}
+	case []interface{}:
+		if !checkStringArray("type", v, path, errs) {
+			return
+		}
+		for i, t := range v {
+			if name := t.(string); !validTypeNames[name] {
+				addSchemaError(errs, fmt.Sprintf("%s/%d", path, i), "type", fmt.Sprintf("invalid type name '%s'", name))
+			}
+		}
+	default:
+		addSchemaError(errs, path, "type", fmt.Sprintf("type must be a string or an array of strings, got %s", jsonTypeName(value)))
+	}
+}
+
+// checkStringArray 检查值为元素不重复的字符串数组，结构正确时返回 true
+func checkStringArray(key string, value interface{}, path string, errs *errors.ValidationErrors) bool {
+	list, ok := value.([]interface{})
+	if !ok {
+		addSchemaError(errs, path, key, fmt.Sprintf("%s must be an array of strings, got %s", key, jsonTypeName(value)))
+		return false
+	}
+	valid := true
+	seen := make(map[string]int, len(list))
+	for i, item := range list {
+		str, ok := item.(string)
+		if !ok {
+			addSchemaError(errs, fmt.Sprintf("%s/%d", path, i), key, fmt.Sprintf("%s items must be strings, got %s", key, jsonTypeName(item)))
+			valid = false
+			continue
+		}
+		if first, dup := seen[str]; dup {
+			addSchemaError(errs, fmt.Sprintf("%s/%d", path, i), key, fmt.Sprintf("%s items must be unique: '%s' duplicates index %d", key, str, first))
+			valid = false
+			continue
+		}
+		seen[str] = i
+	}
+	return valid
+}
+
+// checkSchemaMap 检查值为名称到子schema的映射
+func checkSchemaMap(key string, value interface{}, path string, errs *errors.ValidationErrors) {
+	schemas, ok := value.(map[string]interface{})
+	if !ok {
+		addSchemaError(errs, path, key, fmt.Sprintf("%s must be an object, got %s", key, jsonTypeName(value)))
+		return
+	}
+	for _, name := range sortedKeys(schemas) {
+		checkSchemaNode(schemas[name], path+"/"+escapePointerToken(name), errs)
+	}
+}
+
+// checkSchemaList 检查按位置排列的子schema列表
+func checkSchemaList(list []interface{}, path string, errs *errors.ValidationErrors) {
+	for i, item := range list {
+		checkSchemaNode(item, fmt.Sprintf("%s/%d", path, i), errs)
+	}
+}
+
+// sortedKeys 按名称排序返回对象的键，使错误顺序稳定
+func sortedKeys(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// addSchemaError 追加一个schema结构错误
+func addSchemaError(errs *errors.ValidationErrors, path string, tag string, message string) {
+	*errs = append(*errs, errors.ValidationError{Path: path, Message: message, Tag: tag})
+}
+
+// escapePointerToken 按 JSON Pointer 规则转义路径片段
+func escapePointerToken(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+// jsonTypeName 返回解码后JSON值的类型名称
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
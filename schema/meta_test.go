@@ -0,0 +1,125 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/songzhibin97/jsonschema-validator/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSchema(t *testing.T) {
+	tests := []struct {
+		name       string
+		schemaJSON string
+		// expectPaths 为空表示schema合法
+		expectPaths []string
+		errContains string
+	}{
+		{
+			name:       "Valid schema",
+			schemaJSON: `{"type": "object", "properties": {"name": {"type": ["string", "null"], "minLength": 1}, "tags": {"type": "array", "items": {"type": "string"}}}, "required": ["name"]}`,
+		},
+		{
+			name:       "Boolean subschemas",
+			schemaJSON: `{"properties": {"any": true, "none": false}, "additionalProperties": false}`,
+		},
+		{
+			name:        "Invalid type name",
+			schemaJSON:  `{"type": "strin"}`,
+			expectPaths: []string{"/type"},
+			errContains: "invalid type name 'strin'",
+		},
+		{
+			name:        "Invalid type name in array",
+			schemaJSON:  `{"type": ["string", "int"]}`,
+			expectPaths: []string{"/type/1"},
+			errContains: "invalid type name 'int'",
+		},
+		{
+			name:        "Duplicate required",
+			schemaJSON:  `{"type": "object", "required": ["id", "name", "id"]}`,
+			expectPaths: []string{"/required/2"},
+			errContains: "required items must be unique: 'id' duplicates index 0",
+		},
+		{
+			name:        "Required not strings",
+			schemaJSON:  `{"required": ["id", 1]}`,
+			expectPaths: []string{"/required/1"},
+			errContains: "required items must be strings",
+		},
+		{
+			name:        "Duplicate enum",
+			schemaJSON:  `{"properties": {"role": {"enum": ["admin", "user", "admin"]}}}`,
+			expectPaths: []string{"/properties/role/enum/2"},
+			errContains: `enum items must be unique: "admin" duplicates index 0`,
+		},
+		{
+			name:        "Property schema not an object",
+			schemaJSON:  `{"properties": {"name": "string"}}`,
+			expectPaths: []string{"/properties/name"},
+			errContains: "schema must be an object or a boolean",
+		},
+		{
+			name:        "Numeric keyword not a number",
+			schemaJSON:  `{"minimum": "0", "maxLength": 1.5, "multipleOf": 0}`,
+			expectPaths: []string{"/maxLength", "/minimum", "/multipleOf"},
+		},
+		{
+			name:        "Nested errors",
+			schemaJSON:  `{"properties": {"user": {"properties": {"age": {"type": "number", "minimum": "18"}}}}, "items": [{"type": "nope"}]}`,
+			expectPaths: []string{"/items/0/type", "/properties/user/properties/age/minimum"},
+		},
+		{
+			name:        "Invalid pattern",
+			schemaJSON:  `{"pattern": "(", "patternProperties": {"[": {}}}`,
+			expectPaths: []string{"/pattern", "/patternProperties/["},
+			errContains: "invalid pattern",
+		},
+		{
+			name:        "Empty allOf",
+			schemaJSON:  `{"allOf": []}`,
+			expectPaths: []string{"/allOf"},
+			errContains: "allOf must be a non-empty array of schemas",
+		},
+		{
+			name:        "Root not an object",
+			schemaJSON:  `[]`,
+			expectPaths: []string{""},
+			errContains: "schema must be an object or a boolean, got array",
+		},
+		{
+			name:       "Boolean root true",
+			schemaJSON: `true`,
+		},
+		{
+			name:       "Boolean root false",
+			schemaJSON: `false`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSchema(tt.schemaJSON)
+			if len(tt.expectPaths) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			var errs errors.ValidationErrors
+			if !assert.ErrorAs(t, err, &errs) {
+				return
+			}
+			paths := make([]string, 0, len(errs))
+			for _, e := range errs {
+				paths = append(paths, e.Path)
+			}
+			assert.Equal(t, tt.expectPaths, paths)
+			if tt.errContains != "" {
+				assert.Contains(t, err.Error(), tt.errContains)
+			}
+		})
+	}
+
+	err := ValidateSchema(`{"type": `)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse schema")
+}
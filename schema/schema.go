@@ -1,9 +1,14 @@
 package schema
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"math"
+	"reflect"
 	"regexp"
+	"sort"
+	"strings"
 )
 
 // ValidationMode 定义验证模式
@@ -23,6 +28,10 @@ type Schema struct {
 	Title       string
 	Description string
 	Mode        ValidationMode
+	// MaxPatternLength 限制 pattern 和 patternProperties 中模式字符串的最大长度，0 表示不限制
+	MaxPatternLength int
+	// KnownFormat 非空时在严格模式下编译检查 format 名称，未知格式导致编译失败
+	KnownFormat func(name string) bool
 }
 
 // CompiledSchema 表示编译后的Schema
@@ -30,6 +39,22 @@ type CompiledSchema struct {
 	Keywords   map[string]interface{}
 	TypeRules  map[string][]string
 	SubSchemas map[string]*CompiledSchema
+	// Regexps 保存 pattern 和 patternProperties 中编译后的正则表达式，键为模式字符串
+	Regexps map[string]*regexp.Regexp
+	// Order 是 Keywords 的验证顺序，由 OrderKeywords 在编译时计算
+	Order []string
+	// Boolean 非空时表示布尔schema：true 接受任意值，false 拒绝任意值
+	Boolean *bool
+}
+
+// newBooleanSchema 创建表示布尔schema的编译结果
+func newBooleanSchema(b bool) *CompiledSchema {
+	return &CompiledSchema{
+		Keywords:   make(map[string]interface{}),
+		TypeRules:  make(map[string][]string),
+		SubSchemas: make(map[string]*CompiledSchema),
+		Boolean:    &b,
+	}
 }
 
 // Parse 解析JSON字符串为Schema
@@ -67,6 +92,7 @@ func (s *Schema) Compile() error {
 		Keywords:   make(map[string]interface{}),
 		TypeRules:  make(map[string][]string),
 		SubSchemas: make(map[string]*CompiledSchema),
+		Regexps:    make(map[string]*regexp.Regexp),
 	}
 
 	// 处理类型关键字
@@ -91,8 +117,31 @@ func (s *Schema) Compile() error {
 		}
 	}
 
+	// 严格模式下编译阶段即拒绝未知格式，避免从未收到值的属性中的拼写错误被忽略
+	if format, ok := s.Raw["format"].(string); ok && s.Mode == ModeStrict && s.KnownFormat != nil && !s.KnownFormat(format) {
+		return fmt.Errorf("unknown format '%s' in strict mode", format)
+	}
+
+	// 处理 OpenAPI 风格的 nullable：为 true 时 type 额外接受 null
+	if nullable, ok := s.Raw["nullable"]; ok {
+		b, ok := nullable.(bool)
+		if !ok {
+			return fmt.Errorf("invalid nullable value: expected boolean, got %T", nullable)
+		}
+		compiled.Keywords["nullable"] = b
+	}
+
+	// 处理枚举：预先为字符串成员建立集合，验证时按 O(1) 查找
+	if values, ok := s.Raw["enum"].([]interface{}); ok {
+		// 重复的枚举成员几乎总是笔误，严格模式下编译阶段即拒绝
+		if first, dup, found := duplicateEnumMember(values); found && s.Mode == ModeStrict {
+			return fmt.Errorf("duplicate enum value %s at index %d (first at index %d) in strict mode", enumValueString(values[dup]), dup, first)
+		}
+		compiled.Keywords["enum"] = newEnumSet(values)
+	}
+
 	// 处理数值约束关键字
-	for _, key := range []string{"minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "multipleOf"} {
+	for _, key := range []string{"minimum", "maximum", "multipleOf"} {
 		if val, ok := s.Raw[key]; ok {
 			if num, ok := val.(float64); ok {
 				compiled.Keywords[key] = num
@@ -102,8 +151,37 @@ func (s *Schema) Compile() error {
 		}
 	}
 
+	// 处理排他边界：draft-06+ 为数值形式，draft-04 为与 minimum/maximum 搭配的布尔形式
+	consumed := make(map[string]bool)
+	for _, bound := range [][2]string{{"exclusiveMinimum", "minimum"}, {"exclusiveMaximum", "maximum"}} {
+		exclusive, inclusive := bound[0], bound[1]
+		val, ok := s.Raw[exclusive]
+		if !ok {
+			continue
+		}
+		switch v := val.(type) {
+		case float64:
+			compiled.Keywords[exclusive] = v
+		case bool:
+			consumed[exclusive] = true
+			if !v {
+				continue
+			}
+			limit, hasLimit := compiled.Keywords[inclusive]
+			if !hasLimit {
+				return fmt.Errorf("%s: true requires %s", exclusive, inclusive)
+			}
+			// 布尔形式为 true 时将包含边界转换为排他边界
+			delete(compiled.Keywords, inclusive)
+			consumed[inclusive] = true
+			compiled.Keywords[exclusive] = limit
+		default:
+			return fmt.Errorf("invalid %s value: expected number or boolean, got %T", exclusive, val)
+		}
+	}
+
 	// 处理字符串约束关键字
-	for _, key := range []string{"minLength", "maxLength"} {
+	for _, key := range []string{"minLength", "maxLength", "minBytes", "maxBytes"} {
 		if val, ok := s.Raw[key]; ok {
 			if num, ok := val.(float64); ok {
 				compiled.Keywords[key] = int(num)
@@ -115,12 +193,34 @@ func (s *Schema) Compile() error {
 
 	if pattern, ok := s.Raw["pattern"]; ok {
 		if str, ok := pattern.(string); ok {
+			if err := s.checkPatternLength(str); err != nil {
+				return err
+			}
+			re, err := regexp.Compile(str)
+			if err != nil {
+				return fmt.Errorf("invalid pattern: %s - %w", str, err)
+			}
 			compiled.Keywords["pattern"] = str
+			compiled.Regexps[str] = re
 		} else {
 			return fmt.Errorf("invalid pattern value: expected string, got %T", pattern)
 		}
 	}
 
+	// requiredIfMatch 的模式在编译时检查，并与 pattern 共用 Regexps
+	if cond, ok := s.Raw["requiredIfMatch"].(map[string]interface{}); ok {
+		if str, ok := cond["pattern"].(string); ok {
+			if err := s.checkPatternLength(str); err != nil {
+				return fmt.Errorf("invalid pattern in requiredIfMatch: %w", err)
+			}
+			re, err := regexp.Compile(str)
+			if err != nil {
+				return fmt.Errorf("invalid pattern in requiredIfMatch: %s - %w", str, err)
+			}
+			compiled.Regexps[str] = re
+		}
+	}
+
 	// 处理数组约束关键字
 	for _, key := range []string{"minItems", "maxItems"} {
 		if val, ok := s.Raw[key]; ok {
@@ -132,18 +232,33 @@ func (s *Schema) Compile() error {
 		}
 	}
 
+	// 处理对象属性数量约束关键字，与 ValidateSchema 一样拒绝负数和小数，避免 int 截断
+	for _, key := range []string{"minProperties", "maxProperties"} {
+		if val, ok := s.Raw[key]; ok {
+			num, ok := val.(float64)
+			if !ok {
+				return fmt.Errorf("invalid %s value: expected integer, got %T", key, val)
+			}
+			if num < 0 || num != math.Trunc(num) {
+				return fmt.Errorf("%s must be a non-negative integer, got %v", key, val)
+			}
+			compiled.Keywords[key] = int(num)
+		}
+	}
+
 	// 处理属性关键字
 	if props, ok := s.Raw["properties"].(map[string]interface{}); ok {
 		propSchemas := make(map[string]*CompiledSchema)
 		for propName, propSchema := range props {
+			if b, ok := propSchema.(bool); ok {
+				propSchemas[propName] = newBooleanSchema(b)
+				continue
+			}
 			ps, ok := propSchema.(map[string]interface{})
 			if !ok {
 				return fmt.Errorf("property '%s' must be an object, got %T", propName, propSchema)
 			}
-			subSchema := &Schema{
-				Raw:  ps,
-				Mode: s.Mode,
-			}
+			subSchema := s.subSchema(ps)
 			if err := subSchema.Compile(); err != nil {
 				return fmt.Errorf("failed to compile property '%s': %w", propName, err)
 			}
@@ -156,19 +271,20 @@ func (s *Schema) Compile() error {
 	if patternProps, ok := s.Raw["patternProperties"].(map[string]interface{}); ok {
 		patternSchemas := make(map[string]*CompiledSchema)
 		for pattern, propSchema := range patternProps {
-			_, err := regexp.Compile(pattern)
+			if err := s.checkPatternLength(pattern); err != nil {
+				return fmt.Errorf("invalid pattern in patternProperties: %w", err)
+			}
+			re, err := regexp.Compile(pattern)
 			if err != nil {
 				return fmt.Errorf("invalid pattern in patternProperties: %s - %w", pattern, err)
 			}
+			compiled.Regexps[pattern] = re
 
 			ps, ok := propSchema.(map[string]interface{})
 			if !ok {
 				return fmt.Errorf("pattern property '%s' must be an object, got %T", pattern, propSchema)
 			}
-			subSchema := &Schema{
-				Raw:  ps,
-				Mode: s.Mode,
-			}
+			subSchema := s.subSchema(ps)
 			if err := subSchema.Compile(); err != nil {
 				return fmt.Errorf("failed to compile pattern '%s': %w", pattern, err)
 			}
@@ -193,10 +309,7 @@ func (s *Schema) Compile() error {
 				}
 				depSchemas[depName] = fields
 			case map[string]interface{}:
-				subSchema := &Schema{
-					Raw:  v,
-					Mode: s.Mode,
-				}
+				subSchema := s.subSchema(v)
 				if err := subSchema.Compile(); err != nil {
 					return fmt.Errorf("failed to compile dependency '%s': %w", depName, err)
 				}
@@ -208,47 +321,87 @@ func (s *Schema) Compile() error {
 		compiled.Keywords["dependencies"] = depSchemas
 	}
 
+	// 处理按位置验证的数组元素（draft 2020-12）
+	if prefixItems, ok := s.Raw["prefixItems"]; ok {
+		list, ok := prefixItems.([]interface{})
+		if !ok {
+			return fmt.Errorf("invalid prefixItems value: expected array, got %T", prefixItems)
+		}
+		prefixSchemas, err := s.compileSchemaList("prefixItems", list)
+		if err != nil {
+			return err
+		}
+		compiled.Keywords["prefixItems"] = prefixSchemas
+	}
+
 	// 处理数组元素
 	if items, ok := s.Raw["items"]; ok {
 		switch v := items.(type) {
 		case map[string]interface{}:
-			subSchema := &Schema{
-				Raw:  v,
-				Mode: s.Mode,
-			}
+			subSchema := s.subSchema(v)
 			if err := subSchema.Compile(); err != nil {
 				return fmt.Errorf("failed to compile items: %w", err)
 			}
 			compiled.Keywords["items"] = subSchema.Compiled
 		case []interface{}:
-			itemSchemas := make([]*CompiledSchema, 0, len(v))
-			for i, item := range v {
-				itemMap, ok := item.(map[string]interface{})
-				if !ok {
-					return fmt.Errorf("items[%d] must be an object, got %T", i, item)
-				}
-				subSchema := &Schema{
-					Raw:  itemMap,
-					Mode: s.Mode,
-				}
-				if err := subSchema.Compile(); err != nil {
-					return fmt.Errorf("failed to compile items[%d]: %w", i, err)
-				}
-				itemSchemas = append(itemSchemas, subSchema.Compiled)
+			itemSchemas, err := s.compileSchemaList("items", v)
+			if err != nil {
+				return err
 			}
 			compiled.Keywords["items"] = itemSchemas
+		case bool:
+			// false 表示不允许 prefixItems 之外的元素
+			compiled.Keywords["items"] = v
 		default:
 			return fmt.Errorf("invalid items value: %T", v)
 		}
 	}
 
+	// 处理元组之外的数组元素
+	if additionalItems, ok := s.Raw["additionalItems"]; ok {
+		switch v := additionalItems.(type) {
+		case map[string]interface{}:
+			subSchema := s.subSchema(v)
+			if err := subSchema.Compile(); err != nil {
+				return fmt.Errorf("failed to compile additionalItems: %w", err)
+			}
+			compiled.Keywords["additionalItems"] = subSchema.Compiled
+		case bool:
+			compiled.Keywords["additionalItems"] = v
+		default:
+			return fmt.Errorf("invalid additionalItems value: %T", v)
+		}
+	}
+
+	// 处理子schema定义
+	for _, key := range []string{"$defs", "definitions"} {
+		raw, ok := s.Raw[key]
+		if !ok {
+			continue
+		}
+		defs, ok := raw.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("invalid %s value: expected object, got %T", key, raw)
+		}
+		defSchemas := make(map[string]*CompiledSchema, len(defs))
+		for name, def := range defs {
+			defMap, ok := def.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("definition '%s' must be an object, got %T", name, def)
+			}
+			subSchema := s.subSchema(defMap)
+			if err := subSchema.Compile(); err != nil {
+				return fmt.Errorf("failed to compile definition '%s': %w", name, err)
+			}
+			defSchemas[name] = subSchema.Compiled
+		}
+		compiled.Keywords[key] = defSchemas
+	}
+
 	// 处理额外属性
 	if additionalProps, ok := s.Raw["additionalProperties"]; ok {
 		if schemaMap, ok := additionalProps.(map[string]interface{}); ok {
-			subSchema := &Schema{
-				Raw:  schemaMap,
-				Mode: s.Mode,
-			}
+			subSchema := s.subSchema(schemaMap)
 			if err := subSchema.Compile(); err != nil {
 				return fmt.Errorf("failed to compile additionalProperties: %w", err)
 			}
@@ -282,6 +435,9 @@ func (s *Schema) Compile() error {
 
 	// 处理其他关键字
 	for key, value := range s.Raw {
+		if consumed[key] {
+			continue
+		}
 		if _, exists := compiled.Keywords[key]; !exists {
 			if s.Mode == ModeStrict {
 				if !isMetadataKey(key) && !isKnownValidationKey(key) {
@@ -292,31 +448,207 @@ func (s *Schema) Compile() error {
 		}
 	}
 
+	compiled.Order = OrderKeywords(compiled.Keywords)
 	s.Compiled = compiled
 	return nil
 }
 
+// keywordPriority 定义关键字的验证顺序，数值越小越先验证，未列出的关键字使用 defaultKeywordPriority
+var keywordPriority = map[string]int{
+	"type":             0,
+	"required":         1,
+	"properties":       2,
+	"enum":             3,
+	"const":            4,
+	"minLength":        5,
+	"maxLength":        5,
+	"minimum":          5,
+	"maximum":          5,
+	"exclusiveMinimum": 5,
+	"exclusiveMaximum": 5,
+	"multipleOf":       5,
+	"minItems":         5,
+	"maxItems":         5,
+	"minProperties":    5,
+	"maxProperties":    5,
+	"pattern":          6,
+	"format":           6,
+}
+
+// defaultKeywordPriority 是未列出关键字的优先级
+const defaultKeywordPriority = 7
+
+// KeywordPriority 返回关键字的验证优先级，数值越小越先验证：依次为 type、required、properties、enum、const，
+// 其次是长度和范围约束，再次是 pattern 和 format，其余关键字最后
+func KeywordPriority(keyword string) int {
+	if p, ok := keywordPriority[keyword]; ok {
+		return p
+	}
+	return defaultKeywordPriority
+}
+
+// OrderKeywords 按 KeywordPriority 返回关键字，相同优先级按名称排序；编译后的schema和基于schema映射的验证共用该顺序，
+// 使错误顺序和 StopOnFirstError 返回的错误与入口无关且可预测
+func OrderKeywords(keywords map[string]interface{}) []string {
+	order := make([]string, 0, len(keywords))
+	for key := range keywords {
+		order = append(order, key)
+	}
+	sort.Slice(order, func(i, j int) bool {
+		pi, pj := KeywordPriority(order[i]), KeywordPriority(order[j])
+		if pi != pj {
+			return pi < pj
+		}
+		return order[i] < order[j]
+	})
+	return order
+}
+
+// EnumSet 是编译后的 enum 关键字，保留原始枚举值并为其中的字符串成员建立集合
+type EnumSet struct {
+	values  []interface{}
+	strings map[string]struct{}
+}
+
+// newEnumSet 使用枚举值创建 EnumSet
+func newEnumSet(values []interface{}) *EnumSet {
+	set := &EnumSet{values: values, strings: make(map[string]struct{}, len(values))}
+	for _, v := range values {
+		if str, ok := v.(string); ok {
+			set.strings[str] = struct{}{}
+		}
+	}
+	return set
+}
+
+// duplicateEnumMember 查找第一个与之前成员重复的枚举值，返回首次出现和重复出现的下标
+func duplicateEnumMember(values []interface{}) (int, int, bool) {
+	for j := 1; j < len(values); j++ {
+		for i := 0; i < j; i++ {
+			if reflect.DeepEqual(values[i], values[j]) {
+				return i, j, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// enumValueString 以JSON形式显示枚举值
+func enumValueString(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+// Values 返回原始枚举值
+func (e *EnumSet) Values() []interface{} {
+	return e.values
+}
+
+// HasString 检查字符串是否为枚举成员
+func (e *EnumSet) HasString(str string) bool {
+	_, ok := e.strings[str]
+	return ok
+}
+
+// Definition 按名称查找 $defs 或 definitions 中已编译的定义，name 也可以是 "#/$defs/Name" 形式的引用
+func (c *CompiledSchema) Definition(name string) (*CompiledSchema, error) {
+	for _, key := range []string{"$defs", "definitions"} {
+		lookup := strings.TrimPrefix(name, "#/"+key+"/")
+		if defs, ok := c.Keywords[key].(map[string]*CompiledSchema); ok {
+			if def, ok := defs[lookup]; ok {
+				return def, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("definition '%s' not found", name)
+}
+
+// compileSchemaList 编译按位置排列的子schema列表
+func (s *Schema) compileSchemaList(keyword string, list []interface{}) ([]*CompiledSchema, error) {
+	schemas := make([]*CompiledSchema, 0, len(list))
+	for i, item := range list {
+		itemMap, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s[%d] must be an object, got %T", keyword, i, item)
+		}
+		subSchema := s.subSchema(itemMap)
+		if err := subSchema.Compile(); err != nil {
+			return nil, fmt.Errorf("failed to compile %s[%d]: %w", keyword, i, err)
+		}
+		schemas = append(schemas, subSchema.Compiled)
+	}
+	return schemas, nil
+}
+
 // isMetadataKey 检查关键字是否为元数据
 func isMetadataKey(key string) bool {
-	return key == "$id" || key == "title" || key == "description" || key == "$schema" || key == "$comment"
+	switch key {
+	case "$id", "title", "description", "$schema", "$comment", "default", "examples", "readOnly", "writeOnly":
+		return true
+	}
+	return false
 }
 
 // isKnownValidationKey 检查是否为已知的验证关键字
 func isKnownValidationKey(key string) bool {
 	knownKeys := map[string]bool{
-		"minimum":          true,
-		"maximum":          true,
-		"exclusiveMinimum": true,
-		"exclusiveMaximum": true,
-		"multipleOf":       true,
-		"minLength":        true,
-		"maxLength":        true,
-		"pattern":          true,
-		"format":           true,
-		"minItems":         true,
-		"maxItems":         true,
-		"uniqueItems":      true,
-		"enum":             true,
+		"minimum":           true,
+		"maximum":           true,
+		"exclusiveMinimum":  true,
+		"exclusiveMaximum":  true,
+		"multipleOf":        true,
+		"minLength":         true,
+		"maxLength":         true,
+		"pattern":           true,
+		"format":            true,
+		"formatAny":         true,
+		"minItems":          true,
+		"maxItems":          true,
+		"uniqueItems":       true,
+		"enum":              true,
+		"const":             true,
+		"keyOrder":          true,
+		"minPatternMatches": true,
+		"maxPatternMatches": true,
+		"contentEncoding":   true,
+		"contentMediaType":  true,
+		"compare":           true,
+		"prefixItems":       true,
+		"maxDecimals":       true,
+		"len":               true,
+		"nonEmpty":          true,
+		"empty":             true,
+		"gt":                true,
+		"gte":               true,
+		"lt":                true,
+		"lte":               true,
+		"alpha":             true,
+		"alphanum":          true,
+		"ascii":             true,
+		"numeric":           true,
+		"startswith":        true,
+		"endswith":          true,
+		"containssub":       true,
+		"minBytes":          true,
+		"maxBytes":          true,
+		"geopoint":          true,
+		"additionalItems":   true,
+		"allowedProperties": true,
+		"extends":           true,
+		"nullable":          true,
+		"pastDateTime":      true,
+		"futureDateTime":    true,
+		"minProperties":     true,
+		"maxProperties":     true,
+		"trimmed":           true,
+		"requiredIfMatch":   true,
+		"uniqueBy":          true,
+		"dateFormat":        true,
+		"refProperty":       true,
+		"requireContext":    true,
 	}
 	return knownKeys[key]
 }
@@ -326,6 +658,29 @@ func (s *Schema) SetMode(mode ValidationMode) {
 	s.Mode = mode
 }
 
+// SetMaxPatternLength 设置编译时允许的最大模式字符串长度，0 表示不限制
+func (s *Schema) SetMaxPatternLength(n int) {
+	s.MaxPatternLength = n
+}
+
+// SetFormatChecker 设置编译时检查 format 名称的函数，nil 表示不检查
+func (s *Schema) SetFormatChecker(known func(name string) bool) {
+	s.KnownFormat = known
+}
+
+// subSchema 创建继承当前编译设置的子schema
+func (s *Schema) subSchema(raw map[string]interface{}) *Schema {
+	return &Schema{Raw: raw, Mode: s.Mode, MaxPatternLength: s.MaxPatternLength, KnownFormat: s.KnownFormat}
+}
+
+// checkPatternLength 检查模式字符串是否超过 MaxPatternLength
+func (s *Schema) checkPatternLength(pattern string) error {
+	if s.MaxPatternLength > 0 && len(pattern) > s.MaxPatternLength {
+		return fmt.Errorf("pattern exceeds maximum length of %d: %d characters", s.MaxPatternLength, len(pattern))
+	}
+	return nil
+}
+
 // String 返回Schema的字符串表示
 func (s *Schema) String() string {
 	if s.Raw == nil {
@@ -338,6 +693,21 @@ func (s *Schema) String() string {
 	return string(bytes)
 }
 
+// Canonical 返回规范化的Schema字符串：对象键排序且不含多余空白，语义相同的Schema得到相同结果，可用作缓存键
+func (s *Schema) Canonical() (string, error) {
+	var raw interface{} = s.Raw
+	if s.Raw == nil {
+		raw = map[string]interface{}{}
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(raw); err != nil {
+		return "", fmt.Errorf("failed to canonicalize schema: %w", err)
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
 // MarshalJSON 实现json.Marshaler接口
 func (s *Schema) MarshalJSON() ([]byte, error) {
 	if s.Raw == nil {
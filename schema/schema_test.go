@@ -192,6 +192,43 @@ func TestCompile(t *testing.T) {
 				Mode: ModeLoose,
 			},
 		},
+		{
+			name: "Property count bounds",
+			schema: &Schema{
+				Raw: map[string]interface{}{
+					"type":          "object",
+					"minProperties": float64(1),
+					"maxProperties": float64(3),
+				},
+			},
+		},
+		{
+			name: "Invalid minProperties",
+			schema: &Schema{
+				Raw: map[string]interface{}{
+					"minProperties": "two",
+				},
+			},
+			expectErr: "invalid minProperties value",
+		},
+		{
+			name: "Fractional maxProperties",
+			schema: &Schema{
+				Raw: map[string]interface{}{
+					"maxProperties": 1.5,
+				},
+			},
+			expectErr: "maxProperties must be a non-negative integer, got 1.5",
+		},
+		{
+			name: "Negative minProperties",
+			schema: &Schema{
+				Raw: map[string]interface{}{
+					"minProperties": float64(-1),
+				},
+			},
+			expectErr: "minProperties must be a non-negative integer, got -1",
+		},
 		{
 			name: "Nil raw",
 			schema: &Schema{
@@ -217,6 +254,196 @@ func TestCompile(t *testing.T) {
 	}
 }
 
+func TestCompileExclusiveBounds(t *testing.T) {
+	tests := []struct {
+		name      string
+		raw       map[string]interface{}
+		expect    map[string]interface{}
+		absent    []string
+		expectErr string
+	}{
+		{
+			name:   "Numeric form",
+			raw:    map[string]interface{}{"minimum": 1.0, "exclusiveMaximum": 10.0},
+			expect: map[string]interface{}{"minimum": 1.0, "exclusiveMaximum": 10.0},
+		},
+		{
+			name:   "Boolean true converts minimum",
+			raw:    map[string]interface{}{"minimum": 5.0, "exclusiveMinimum": true},
+			expect: map[string]interface{}{"exclusiveMinimum": 5.0},
+			absent: []string{"minimum"},
+		},
+		{
+			name:   "Boolean false keeps maximum",
+			raw:    map[string]interface{}{"maximum": 5.0, "exclusiveMaximum": false},
+			expect: map[string]interface{}{"maximum": 5.0},
+			absent: []string{"exclusiveMaximum"},
+		},
+		{
+			name:      "Boolean true without minimum",
+			raw:       map[string]interface{}{"exclusiveMinimum": true},
+			expectErr: "exclusiveMinimum: true requires minimum",
+		},
+		{
+			name:      "Invalid type",
+			raw:       map[string]interface{}{"exclusiveMaximum": "10"},
+			expectErr: "invalid exclusiveMaximum value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Schema{Raw: tt.raw, Mode: ModeStrict}
+			err := s.Compile()
+			if tt.expectErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+				return
+			}
+			assert.NoError(t, err)
+			for key, want := range tt.expect {
+				assert.Equal(t, want, s.Compiled.Keywords[key])
+			}
+			for _, key := range tt.absent {
+				assert.NotContains(t, s.Compiled.Keywords, key)
+			}
+		})
+	}
+}
+
+func TestCompilePrefixItems(t *testing.T) {
+	s := &Schema{
+		Raw: map[string]interface{}{
+			"type":        "array",
+			"prefixItems": []interface{}{map[string]interface{}{"type": "string"}},
+			"items":       false,
+		},
+		Mode: ModeStrict,
+	}
+	assert.NoError(t, s.Compile())
+	prefix, ok := s.Compiled.Keywords["prefixItems"].([]*CompiledSchema)
+	if assert.True(t, ok) {
+		assert.Len(t, prefix, 1)
+	}
+	assert.Equal(t, false, s.Compiled.Keywords["items"])
+
+	s = &Schema{Raw: map[string]interface{}{"prefixItems": []interface{}{"string"}}}
+	err := s.Compile()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "prefixItems[0] must be an object")
+}
+
+func TestCompileBooleanPropertySchemas(t *testing.T) {
+	s := &Schema{
+		Raw: map[string]interface{}{
+			"properties": map[string]interface{}{"any": true, "none": false},
+		},
+	}
+	assert.NoError(t, s.Compile())
+	props := s.Compiled.Keywords["properties"].(map[string]*CompiledSchema)
+	if assert.NotNil(t, props["any"].Boolean) {
+		assert.True(t, *props["any"].Boolean)
+	}
+	if assert.NotNil(t, props["none"].Boolean) {
+		assert.False(t, *props["none"].Boolean)
+	}
+}
+
+func TestCompileEnumSet(t *testing.T) {
+	s := &Schema{Raw: map[string]interface{}{"enum": []interface{}{"a", "b", float64(1)}}}
+	assert.NoError(t, s.Compile())
+	set, ok := s.Compiled.Keywords["enum"].(*EnumSet)
+	if assert.True(t, ok) {
+		assert.Equal(t, []interface{}{"a", "b", float64(1)}, set.Values())
+		assert.True(t, set.HasString("b"))
+		assert.False(t, set.HasString("1"))
+	}
+}
+
+func TestCompileDuplicateEnum(t *testing.T) {
+	tests := []struct {
+		name        string
+		schemaJSON  string
+		mode        ValidationMode
+		expectError string
+	}{
+		{"Unique members", `{"enum": ["a", "b", 1, "1", {"k": 1}]}`, ModeStrict, ""},
+		{"Duplicate string", `{"enum": ["a", "b", "a"]}`, ModeStrict, `duplicate enum value "a" at index 2 (first at index 0) in strict mode`},
+		{"Duplicate object", `{"enum": [{"k": 1}, null, {"k": 1}]}`, ModeStrict, `duplicate enum value {"k":1} at index 2`},
+		{"Duplicate in subschema", `{"properties": {"role": {"enum": ["admin", "admin"]}}}`, ModeStrict, "duplicate enum value"},
+		{"Duplicate allowed in loose mode", `{"enum": ["a", "a"]}`, ModeLoose, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.schemaJSON)
+			assert.NoError(t, err)
+			s.SetMode(tt.mode)
+			err = s.Compile()
+			if tt.expectError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+			}
+		})
+	}
+}
+
+func TestCompileRegexps(t *testing.T) {
+	s := &Schema{Raw: map[string]interface{}{
+		"type":              "object",
+		"pattern":           "^[a-z]+$",
+		"patternProperties": map[string]interface{}{"^x-": map[string]interface{}{"type": "string"}},
+	}}
+	assert.NoError(t, s.Compile())
+	if assert.Contains(t, s.Compiled.Regexps, "^[a-z]+$") {
+		assert.True(t, s.Compiled.Regexps["^[a-z]+$"].MatchString("abc"))
+	}
+	if assert.Contains(t, s.Compiled.Regexps, "^x-") {
+		assert.True(t, s.Compiled.Regexps["^x-"].MatchString("x-trace"))
+	}
+
+	invalid := &Schema{Raw: map[string]interface{}{"pattern": "("}}
+	err := invalid.Compile()
+	assert.ErrorContains(t, err, "invalid pattern")
+
+	invalid = &Schema{Raw: map[string]interface{}{"patternProperties": map[string]interface{}{"(": map[string]interface{}{}}}}
+	err = invalid.Compile()
+	assert.ErrorContains(t, err, "invalid pattern in patternProperties")
+
+	cond := &Schema{Raw: map[string]interface{}{"requiredIfMatch": map[string]interface{}{"field": "email", "pattern": "@corp$", "require": []interface{}{"id"}}}}
+	assert.NoError(t, cond.Compile())
+	assert.Contains(t, cond.Compiled.Regexps, "@corp$")
+
+	invalid = &Schema{Raw: map[string]interface{}{"requiredIfMatch": map[string]interface{}{"field": "email", "pattern": "(", "require": []interface{}{"id"}}}}
+	err = invalid.Compile()
+	assert.ErrorContains(t, err, "invalid pattern in requiredIfMatch")
+}
+
+func TestCompileMaxPatternLength(t *testing.T) {
+	s := &Schema{Raw: map[string]interface{}{
+		"properties": map[string]interface{}{"code": map[string]interface{}{"pattern": "^[a-z]{1,8}$"}},
+	}}
+	s.SetMaxPatternLength(8)
+	assert.ErrorContains(t, s.Compile(), "pattern exceeds maximum length of 8")
+
+	s.SetMaxPatternLength(0)
+	assert.NoError(t, s.Compile())
+}
+
+func TestCompileKeywordOrder(t *testing.T) {
+	s, err := Parse(`{"pattern": "^a", "const": "a", "type": "string", "maxLength": 3, "enum": ["a"], "format": "email"}`)
+	assert.NoError(t, err)
+	assert.NoError(t, s.Compile())
+	assert.Equal(t, []string{"type", "enum", "const", "maxLength", "format", "pattern"}, s.Compiled.Order)
+
+	s, err = Parse(`{"minProperties": 1, "properties": {}, "required": ["a"], "type": "object", "additionalProperties": false}`)
+	assert.NoError(t, err)
+	assert.NoError(t, s.Compile())
+	assert.Equal(t, []string{"type", "required", "properties", "minProperties", "additionalProperties"}, s.Compiled.Order)
+}
+
 func TestSetMode(t *testing.T) {
 	s := &Schema{}
 	s.SetMode(ModeLoose)
@@ -265,6 +492,34 @@ func TestString(t *testing.T) {
 	}
 }
 
+func TestCanonical(t *testing.T) {
+	a, err := Parse(`{
+		"type": "object",
+		"properties": {"name": {"type": "string", "minLength": 1}, "age": {"minimum": 0, "type": "integer"}},
+		"required": ["name"]
+	}`)
+	assert.NoError(t, err)
+	b, err := Parse(`{"required":["name"],"properties":{"age":{"type":"integer","minimum":0.0},"name":{"minLength":1,"type":"string"}},"type":"object"}`)
+	assert.NoError(t, err)
+
+	ca, err := a.Canonical()
+	assert.NoError(t, err)
+	cb, err := b.Canonical()
+	assert.NoError(t, err)
+	assert.Equal(t, ca, cb)
+	assert.Equal(t, `{"properties":{"age":{"minimum":0,"type":"integer"},"name":{"minLength":1,"type":"string"}},"required":["name"],"type":"object"}`, ca)
+
+	c, err := Parse(`{"pattern": "^<a&b>$"}`)
+	assert.NoError(t, err)
+	cc, err := c.Canonical()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"pattern":"^<a&b>$"}`, cc)
+
+	empty, err := (&Schema{}).Canonical()
+	assert.NoError(t, err)
+	assert.Equal(t, "{}", empty)
+}
+
 func TestMarshalJSON(t *testing.T) {
 	tests := []struct {
 		name   string
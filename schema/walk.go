@@ -0,0 +1,57 @@
+package schema
+
+import "fmt"
+
+// Walk 按 JSON Pointer 先序遍历schema及其所有对象形式的子schema，根schema的指针为 ""；
+// 子schema来自 properties、patternProperties、$defs/definitions、dependencies、items、prefixItems、
+// additionalItems、additionalProperties、contains、propertyNames、not、if/then/else 和 allOf/anyOf/oneOf，
+// 同一层的名称按字典序访问，布尔schema不会传给 fn。fn 返回错误时停止遍历并返回该错误
+func (s *Schema) Walk(fn func(pointer string, sub map[string]interface{}) error) error {
+	if s.Raw == nil {
+		return nil
+	}
+	return walkSchema(s.Raw, "", fn)
+}
+
+// walkSchema 访问单个子schema，再按关键字递归访问其子schema
+func walkSchema(node interface{}, pointer string, fn func(pointer string, sub map[string]interface{}) error) error {
+	obj, ok := node.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	if err := fn(pointer, obj); err != nil {
+		return err
+	}
+
+	for _, key := range sortedKeys(obj) {
+		keyPointer := pointer + "/" + escapePointerToken(key)
+		switch key {
+		case "properties", "patternProperties", "$defs", "definitions", "dependencies":
+			// dependencies 中数组形式的依赖不是schema，walkSchema 会跳过
+			schemas, _ := obj[key].(map[string]interface{})
+			for _, name := range sortedKeys(schemas) {
+				if err := walkSchema(schemas[name], keyPointer+"/"+escapePointerToken(name), fn); err != nil {
+					return err
+				}
+			}
+		case "items", "prefixItems", "allOf", "anyOf", "oneOf":
+			list, ok := obj[key].([]interface{})
+			if !ok {
+				if err := walkSchema(obj[key], keyPointer, fn); err != nil {
+					return err
+				}
+				continue
+			}
+			for i, item := range list {
+				if err := walkSchema(item, fmt.Sprintf("%s/%d", keyPointer, i), fn); err != nil {
+					return err
+				}
+			}
+		case "additionalItems", "additionalProperties", "contains", "propertyNames", "not", "if", "then", "else":
+			if err := walkSchema(obj[key], keyPointer, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
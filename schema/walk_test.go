@@ -0,0 +1,66 @@
+package schema
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWalk(t *testing.T) {
+	s, err := Parse(`{
+		"type": "object",
+		"properties": {
+			"name": {"type": "string"},
+			"tags": {"type": "array", "items": {"type": "string"}},
+			"address": {
+				"type": "object",
+				"properties": {"a/b": {"type": "string"}},
+				"additionalProperties": {"type": "string"}
+			},
+			"any": true
+		},
+		"patternProperties": {"^x-": {"type": "string"}},
+		"oneOf": [{"required": ["name"]}, {"not": {"required": ["tags"]}}],
+		"$defs": {"id": {"type": "integer"}},
+		"default": {"properties": {"ignored": {}}}
+	}`)
+	assert.NoError(t, err)
+
+	var pointers []string
+	assert.NoError(t, s.Walk(func(pointer string, sub map[string]interface{}) error {
+		pointers = append(pointers, pointer)
+		return nil
+	}))
+	assert.Equal(t, []string{
+		"",
+		"/$defs/id",
+		"/oneOf/0",
+		"/oneOf/1",
+		"/oneOf/1/not",
+		"/patternProperties/^x-",
+		"/properties/address",
+		"/properties/address/additionalProperties",
+		"/properties/address/properties/a~1b",
+		"/properties/name",
+		"/properties/tags",
+		"/properties/tags/items",
+	}, pointers)
+
+	// 回调返回错误时停止遍历
+	visited := 0
+	err = s.Walk(func(pointer string, sub map[string]interface{}) error {
+		visited++
+		if pointer == "/oneOf/0" {
+			return fmt.Errorf("stop at %s", pointer)
+		}
+		return nil
+	})
+	assert.EqualError(t, err, "stop at /oneOf/0")
+	assert.Equal(t, 3, visited)
+
+	assert.NoError(t, (&Schema{}).Walk(func(string, map[string]interface{}) error {
+		t.Fatal("empty schema has no subschemas")
+		return nil
+	}))
+}
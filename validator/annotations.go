@@ -0,0 +1,33 @@
+package validator
+
+// Annotation 记录实例路径上由schema注解关键字产生的值
+type Annotation struct {
+	Path    string      `json:"path"`
+	Keyword string      `json:"keyword"`
+	Value   interface{} `json:"value"`
+}
+
+// annotationKeywords 按输出顺序列出收集的注解关键字
+var annotationKeywords = []string{"title", "description", "default", "examples", "readOnly", "writeOnly"}
+
+// isAnnotationKey 检查关键字是否为注解关键字
+func isAnnotationKey(key string) bool {
+	for _, keyword := range annotationKeywords {
+		if key == keyword {
+			return true
+		}
+	}
+	return false
+}
+
+// collectAnnotations 在启用 CollectAnnotations 时记录当前schema在 path 处的注解
+func (v *Validator) collectAnnotations(result *ValidationResult, keywords map[string]interface{}, path string) {
+	if !v.opts.CollectAnnotations {
+		return
+	}
+	for _, keyword := range annotationKeywords {
+		if value, ok := keywords[keyword]; ok {
+			result.Annotations = append(result.Annotations, Annotation{Path: path, Keyword: keyword, Value: value})
+		}
+	}
+}
@@ -205,3 +205,25 @@ func TestComplexNestedSchema(t *testing.T) {
 		})
 	}
 }
+
+func TestConstComplexValues(t *testing.T) {
+	v := New()
+	schemaJSON := `{
+		"type": "object",
+		"properties": {
+			"config": {"const": {"mode": "fast", "limits": [1, 2, {"max": 10}]}}
+		}
+	}`
+
+	result, err := v.ValidateJSON(`{"config":{"limits":[1.0,2,{"max":10.0}],"mode":"fast"}}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+
+	result, err = v.ValidateJSON(`{"config":{"limits":[1,2,{"max":11}],"mode":"fast"}}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "const", result.Errors[0].Tag)
+		assert.Equal(t, "$.config", result.Errors[0].Path)
+	}
+}
@@ -0,0 +1,83 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/songzhibin97/jsonschema-validator/errors"
+)
+
+// crossFieldRule 描述跨字段标签对应的比较器及错误描述
+type crossFieldRule struct {
+	comparator  string
+	description string
+}
+
+// crossFieldRules 将跨字段标签映射到已注册的比较器
+var crossFieldRules = map[string]crossFieldRule{
+	"eqfield": {comparator: "eq", description: "equal to"},
+	"nefield": {comparator: "ne", description: "not equal to"},
+	"gtfield": {comparator: "gt", description: "greater than"},
+	"gefield": {comparator: "ge", description: "greater than or equal to"},
+	"ltfield": {comparator: "lt", description: "less than"},
+	"lefield": {comparator: "le", description: "less than or equal to"},
+}
+
+// crossFieldTags 保证跨字段标签按固定顺序处理
+var crossFieldTags = []string{"eqfield", "nefield", "gtfield", "gefield", "ltfield", "lefield"}
+
+// validateCrossFields 处理 eqfield/gtfield 等跨字段标签：从同一结构体中取出指定字段，
+// 使用注册的比较器与当前字段比较。已处理的标签会从 schemaMap 中移除
+func (v *Validator) validateCrossFields(parent reflect.Value, fieldValue interface{}, schemaMap map[string]interface{}, path string) ([]errors.ValidationError, error) {
+	var errs []errors.ValidationError
+	for _, tag := range crossFieldTags {
+		param, ok := schemaMap[tag]
+		if !ok {
+			continue
+		}
+		delete(schemaMap, tag)
+		rule := crossFieldRules[tag]
+
+		otherName, ok := param.(string)
+		if !ok || otherName == "" {
+			return nil, &errors.ValidationError{
+				Path:    path,
+				Message: fmt.Sprintf("%s requires a field name", tag),
+				Tag:     tag,
+			}
+		}
+		other := parent.FieldByName(otherName)
+		if !other.IsValid() || !other.CanInterface() {
+			return nil, &errors.ValidationError{
+				Path:    path,
+				Message: fmt.Sprintf("%s references unknown field '%s'", tag, otherName),
+				Tag:     tag,
+				Param:   otherName,
+			}
+		}
+		otherValue := other.Interface()
+		if v.customTypeFunc != nil {
+			otherValue = v.customTypeFunc(other)
+		}
+
+		compare := v.GetComparator(rule.comparator)
+		if compare == nil {
+			return nil, &errors.ValidationError{
+				Path:    path,
+				Message: fmt.Sprintf("no comparator registered for '%s'", rule.comparator),
+				Tag:     tag,
+				Param:   otherName,
+			}
+		}
+		if !compare(fieldValue, otherValue) {
+			errs = append(errs, errors.ValidationError{
+				Path:    path,
+				Message: fmt.Sprintf("field must be %s %s", rule.description, otherName),
+				Value:   fieldValue,
+				Tag:     tag,
+				Param:   otherName,
+			})
+		}
+	}
+	return errs, nil
+}
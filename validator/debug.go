@@ -0,0 +1,78 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/songzhibin97/jsonschema-validator/errors"
+	"github.com/songzhibin97/jsonschema-validator/schema"
+)
+
+// DebugString 返回验证器当前配置及注册项数量的摘要，便于日志记录和问题排查
+func (v *Validator) DebugString() string {
+	v.lock.RLock()
+	validatorCount := len(v.validators)
+	comparatorCount := len(v.comparators)
+	v.lock.RUnlock()
+
+	var b strings.Builder
+	b.WriteString("Validator{")
+	fmt.Fprintf(&b, "tagName=%q", v.opts.TagName)
+	fmt.Fprintf(&b, ", mode=%s", validationModeName(v.opts.ValidationMode))
+	fmt.Fprintf(&b, ", errorFormatting=%s", formattingModeName(v.opts.ErrorFormattingMode))
+	fmt.Fprintf(&b, ", caching=%t", v.opts.EnableCaching)
+	fmt.Fprintf(&b, ", recursive=%t", v.opts.RecursiveValidation)
+	fmt.Fprintf(&b, ", untaggedNested=%t", v.opts.UntaggedNestedValidation)
+	fmt.Fprintf(&b, ", stopOnFirstError=%t", v.opts.StopOnFirstError)
+	fmt.Fprintf(&b, ", errorLimit=%d", v.opts.ErrorLimit)
+	fmt.Fprintf(&b, ", allowUnknownFields=%t", v.opts.AllowUnknownFields)
+	fmt.Fprintf(&b, ", preserveKeyOrder=%t", v.opts.PreserveKeyOrder)
+	fmt.Fprintf(&b, ", byteLength=%t", v.opts.ByteLength)
+	fmt.Fprintf(&b, ", formatAssertion=%t", v.opts.FormatAssertion)
+	fmt.Fprintf(&b, ", unknownFormatAssertion=%t", v.opts.UnknownFormatAssertion)
+	fmt.Fprintf(&b, ", collectAnnotations=%t", v.opts.CollectAnnotations)
+	fmt.Fprintf(&b, ", jsonFieldNames=%t", v.opts.JSONFieldNames)
+	fmt.Fprintf(&b, ", maxDocumentBytes=%d", v.opts.MaxDocumentBytes)
+	fmt.Fprintf(&b, ", coerceTypes=%t", v.opts.CoerceTypes)
+	fmt.Fprintf(&b, ", rootPath=%q", v.opts.RootPath)
+	fmt.Fprintf(&b, ", coverage=%t", v.opts.Coverage)
+	fmt.Fprintf(&b, ", maxPatternLength=%d", v.opts.MaxPatternLength)
+	fmt.Fprintf(&b, ", implicitObjectType=%t", v.opts.ImplicitObjectType)
+	fmt.Fprintf(&b, ", strictTypes=%t", v.opts.StrictTypes)
+	fmt.Fprintf(&b, ", numberParser=%t", v.opts.NumberParser != nil)
+	fmt.Fprintf(&b, ", messages=%d", len(v.opts.Messages))
+	fmt.Fprintf(&b, ", translator=%t", v.translator != nil)
+	fmt.Fprintf(&b, ", validators=%d", validatorCount)
+	fmt.Fprintf(&b, ", comparators=%d", comparatorCount)
+	fmt.Fprintf(&b, ", formats=%d", len(v.formats.Names()))
+	b.WriteString("}")
+	return b.String()
+}
+
+// validationModeName 返回验证模式的可读名称
+func validationModeName(mode schema.ValidationMode) string {
+	switch mode {
+	case schema.ModeStrict:
+		return "strict"
+	case schema.ModeLoose:
+		return "loose"
+	case schema.ModeWarn:
+		return "warn"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(mode))
+	}
+}
+
+// formattingModeName 返回错误格式化模式的可读名称
+func formattingModeName(mode errors.FormattingMode) string {
+	switch mode {
+	case errors.FormattingModeSimple:
+		return "simple"
+	case errors.FormattingModeDetailed:
+		return "detailed"
+	case errors.FormattingModeJSON:
+		return "json"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(mode))
+	}
+}
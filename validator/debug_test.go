@@ -0,0 +1,68 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/songzhibin97/jsonschema-validator/rules"
+	"github.com/songzhibin97/jsonschema-validator/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebugString(t *testing.T) {
+	v := New(
+		WithTagName("json"),
+		WithValidationMode(schema.ModeLoose),
+		WithCaching(true),
+		WithStopOnFirstError(true),
+	)
+
+	out := v.DebugString()
+	for _, want := range []string{
+		`tagName="json"`,
+		"mode=loose",
+		"errorFormatting=detailed",
+		"caching=true",
+		"stopOnFirstError=true",
+		"allowUnknownFields=false",
+		fmt.Sprintf("validators=%d", len(v.ListValidators())),
+		fmt.Sprintf("comparators=%d", len(v.ListComparators())),
+		fmt.Sprintf("formats=%d", len(rules.ListFormats())),
+	} {
+		assert.Contains(t, out, want)
+	}
+}
+
+func TestDebugStringOptions(t *testing.T) {
+	v := New(
+		WithUntaggedNestedValidation(true),
+		WithJSONFieldNames(true),
+		WithMaxDocumentBytes(1024),
+		WithCoerceTypes(true),
+		WithRootPath("body"),
+		WithCoverage(true),
+		WithMaxPatternLength(64),
+		WithImplicitObjectType(true),
+		WithErrorLimit(5),
+		WithStrictTypes(true),
+		WithNumberParser(func(n json.Number) (interface{}, error) { return n.Float64() }),
+	)
+
+	out := v.DebugString()
+	for _, want := range []string{
+		"untaggedNested=true",
+		"jsonFieldNames=true",
+		"maxDocumentBytes=1024",
+		"coerceTypes=true",
+		`rootPath="body"`,
+		"coverage=true",
+		"maxPatternLength=64",
+		"implicitObjectType=true",
+		"errorLimit=5",
+		"strictTypes=true",
+		"numberParser=true",
+	} {
+		assert.Contains(t, out, want)
+	}
+}
@@ -0,0 +1,78 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/songzhibin97/jsonschema-validator/schema"
+)
+
+// ValidateAndApplyDefaults 解码JSON数据，按schema中 properties 的 default 为缺失的属性填充默认值后再验证，
+// 返回填充后的数据和验证结果；嵌套对象中的默认值同样会被填充
+func (v *Validator) ValidateAndApplyDefaults(jsonData string, schemaJSON string) (interface{}, *ValidationResult, error) {
+	if v.opts.MaxDocumentBytes > 0 && int64(len(jsonData)) > v.opts.MaxDocumentBytes {
+		return nil, nil, v.documentTooLargeError()
+	}
+
+	// 与 ValidateJSON 使用相同的解码方式，使 WithStrictTypes、WithNumberParser 同样生效
+	data, err := v.decodeJSON(jsonData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid JSON data: %w", err)
+	}
+
+	s, err := v.compiledSchema(schemaJSON)
+	if err != nil {
+		return nil, nil, err
+	}
+	applyDefaults(data, s.Compiled)
+
+	// 与其他入口一致，出现非验证错误时仍返回填充后的数据和已收集的部分结果
+	result, err := v.finalizeResult(v.validateCompiled(context.Background(), data, s, v.rootPath()))
+	return data, result, err
+}
+
+// applyDefaults 为对象中缺失且声明了 default 的属性写入默认值的副本，并递归处理已存在的属性
+func applyDefaults(value interface{}, compiled *schema.CompiledSchema) {
+	obj, ok := value.(map[string]interface{})
+	if !ok || compiled == nil {
+		return
+	}
+	props, ok := compiled.Keywords["properties"].(map[string]*schema.CompiledSchema)
+	if !ok {
+		return
+	}
+	for name, prop := range props {
+		if prop == nil {
+			continue
+		}
+		if _, exists := obj[name]; !exists {
+			def, ok := prop.Keywords["default"]
+			if !ok {
+				continue
+			}
+			// 复制默认值，避免填充后的数据与（可能被缓存的）schema 共享同一个 map 或切片
+			obj[name] = copyDefault(def)
+		}
+		applyDefaults(obj[name], prop)
+	}
+}
+
+// copyDefault 深度复制JSON解码得到的默认值
+func copyDefault(value interface{}) interface{} {
+	switch val := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			copied[k] = copyDefault(item)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(val))
+		for i, item := range val {
+			copied[i] = copyDefault(item)
+		}
+		return copied
+	default:
+		return val
+	}
+}
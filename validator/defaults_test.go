@@ -0,0 +1,97 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/songzhibin97/jsonschema-validator/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAndApplyDefaults(t *testing.T) {
+	schemaJSON := `{
+		"type": "object",
+		"required": ["name", "status"],
+		"properties": {
+			"name": {"type": "string"},
+			"status": {"type": "string", "enum": ["active", "disabled"], "default": "active"},
+			"settings": {
+				"type": "object",
+				"default": {},
+				"properties": {"tags": {"type": "array", "default": ["new"]}}
+			}
+		}
+	}`
+	v := New(WithCaching(true))
+
+	data, result, err := v.ValidateAndApplyDefaults(`{"name": "ann"}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid, "%v", result.Errors)
+	assert.Equal(t, map[string]interface{}{
+		"name":     "ann",
+		"status":   "active",
+		"settings": map[string]interface{}{"tags": []interface{}{"new"}},
+	}, data)
+
+	// 已存在的值不会被覆盖，填充的默认值不与schema共享
+	data, result, err = v.ValidateAndApplyDefaults(`{"name": "bob", "status": "paused"}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	obj := data.(map[string]interface{})
+	assert.Equal(t, "paused", obj["status"])
+	obj["settings"].(map[string]interface{})["tags"].([]interface{})[0] = "changed"
+
+	data, _, err = v.ValidateAndApplyDefaults(`{"name": "cat"}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"new"}, data.(map[string]interface{})["settings"].(map[string]interface{})["tags"])
+
+	_, _, err = v.ValidateAndApplyDefaults(`{`, schemaJSON)
+	assert.Error(t, err)
+}
+
+func TestValidateAndApplyDefaultsStrictTypes(t *testing.T) {
+	schemaJSON := `{"type": "object", "properties": {"age": {"type": "integer"}, "retries": {"type": "integer", "default": 3}}}`
+	v := New(WithStrictTypes(true))
+
+	data, result, err := v.ValidateAndApplyDefaults(`{"age": 30}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid, "%v", result.Errors)
+	assert.Equal(t, float64(3), data.(map[string]interface{})["retries"])
+
+	_, result, err = v.ValidateAndApplyDefaults(`{"age": 30.0}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "$.age", result.Errors[0].Path)
+		assert.Equal(t, "type", result.Errors[0].Tag)
+	}
+
+	_, _, err = v.ValidateAndApplyDefaults(`{"age": 30} {}`, schemaJSON)
+	assert.Error(t, err)
+}
+
+func TestValidateAndApplyDefaultsPartialResult(t *testing.T) {
+	schemaJSON := `{
+		"type": "object",
+		"required": ["id"],
+		"properties": {
+			"status": {"type": "string", "default": "active"},
+			"user": {"type": "object", "patternProperties": {"^x-": {}}}
+		}
+	}`
+	v := New(WithCaching(true))
+	s, err := v.compiledSchema(schemaJSON)
+	assert.NoError(t, err)
+	// 模拟验证到一半时遇到的非验证错误：将缓存的schema替换为无法编译的模式
+	user := s.Compiled.Keywords["properties"].(map[string]*schema.CompiledSchema)["user"]
+	user.Keywords["patternProperties"] = map[string]*schema.CompiledSchema{"(": user.Keywords["patternProperties"].(map[string]*schema.CompiledSchema)["^x-"]}
+
+	data, result, err := v.ValidateAndApplyDefaults(`{"user": {"x-a": 1}}`, schemaJSON)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "invalid pattern in patternProperties")
+	}
+	assert.Equal(t, "active", data.(map[string]interface{})["status"])
+	if assert.NotNil(t, result) && assert.Len(t, result.Errors, 1) {
+		assert.False(t, result.Valid)
+		assert.Equal(t, "$.id", result.Errors[0].Path)
+	}
+}
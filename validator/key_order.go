@@ -0,0 +1,69 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// decodeKeyOrders 按出现顺序记录JSON中每个对象的键，结果以 data（同一JSON解码后的值）中对应对象的地址为索引；
+// 按对象而非拼接的路径索引，避免 {"a.b":{}} 与 {"a":{"b":{}}} 这类键中含分隔符的对象互相覆盖
+func decodeKeyOrders(jsonData string, data interface{}) (map[uintptr][]string, error) {
+	orders := make(map[uintptr][]string)
+	dec := json.NewDecoder(strings.NewReader(jsonData))
+	if err := collectKeyOrders(dec, data, orders); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// collectKeyOrders 递归读取一个JSON值，并将其中对象键的顺序记录到 node 中对应的对象上
+func collectKeyOrders(dec *json.Decoder, node interface{}, orders map[uintptr][]string) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return nil
+	}
+
+	switch delim {
+	case '{':
+		obj, _ := node.(map[string]interface{})
+		keys := make([]string, 0)
+		for dec.More() {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			key, ok := keyTok.(string)
+			if !ok {
+				return fmt.Errorf("unexpected object key %v", keyTok)
+			}
+			keys = append(keys, key)
+			if err := collectKeyOrders(dec, obj[key], orders); err != nil {
+				return err
+			}
+		}
+		if obj != nil {
+			orders[reflect.ValueOf(obj).Pointer()] = keys
+		}
+	case '[':
+		arr, _ := node.([]interface{})
+		for i := 0; dec.More(); i++ {
+			var item interface{}
+			if i < len(arr) {
+				item = arr[i]
+			}
+			if err := collectKeyOrders(dec, item, orders); err != nil {
+				return err
+			}
+		}
+	}
+
+	// 读取结束分隔符
+	_, err = dec.Token()
+	return err
+}
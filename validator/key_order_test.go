@@ -0,0 +1,69 @@
+package validator
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeKeyOrders(t *testing.T) {
+	jsonData := `{"b":1,"a":{"y":[{"q":1,"p":2}],"x":null}}`
+	var data map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(jsonData), &data))
+	a := data["a"].(map[string]interface{})
+	item := a["y"].([]interface{})[0].(map[string]interface{})
+
+	orders, err := decodeKeyOrders(jsonData, data)
+	assert.NoError(t, err)
+	assert.Equal(t, map[uintptr][]string{
+		reflect.ValueOf(data).Pointer(): {"b", "a"},
+		reflect.ValueOf(a).Pointer():    {"y", "x"},
+		reflect.ValueOf(item).Pointer(): {"q", "p"},
+	}, orders)
+
+	_, err = decodeKeyOrders(`{"a":`, nil)
+	assert.Error(t, err)
+}
+
+func TestPreserveKeyOrder(t *testing.T) {
+	v := New(WithPreserveKeyOrder(true))
+	schemaJSON := `{
+		"type": "object",
+		"keyOrder": ["id", "amount", "currency"],
+		"properties": {
+			"meta": {"type": "object", "keyOrder": ["nonce", "ts"]}
+		}
+	}`
+
+	result, err := v.ValidateJSON(`{"id":1,"amount":10,"currency":"EUR","meta":{"nonce":"n","ts":1}}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+
+	result, err = v.ValidateJSON(`{"amount":10,"id":1,"currency":"EUR","meta":{"ts":1,"nonce":"n"}}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	if assert.Len(t, result.Errors, 2) {
+		paths := []string{result.Errors[0].Path, result.Errors[1].Path}
+		assert.ElementsMatch(t, []string{"$", "$.meta"}, paths)
+		assert.Equal(t, "keyOrder", result.Errors[0].Tag)
+	}
+
+	// 键中含 "." 的对象与嵌套对象不会互相覆盖
+	nested := `{"properties": {"a.b": {"keyOrder": ["x", "y"]}, "a": {"properties": {"b": {"keyOrder": ["y", "x"]}}}}}`
+	result, err = v.ValidateJSON(`{"a.b":{"x":1,"y":2},"a":{"b":{"y":1,"x":2}}}`, nested)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid, "%v", result.Errors)
+
+	result, err = v.ValidateJSON(`{"a":{"b":{"y":1,"x":2}},"a.b":{"y":1,"x":2}}`, nested)
+	assert.NoError(t, err)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "keyOrder", result.Errors[0].Tag)
+	}
+
+	// 未开启时 keyOrder 无法验证
+	result, err = New().ValidateJSON(`{"id":1}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+}
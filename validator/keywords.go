@@ -0,0 +1,27 @@
+package validator
+
+import (
+	"sort"
+
+	"github.com/songzhibin97/jsonschema-validator/schema"
+)
+
+// sortedPropertyNames 按名称顺序返回属性名
+func sortedPropertyNames(props map[string]interface{}) []string {
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sortedSchemaNames 按名称顺序返回编译后子schema映射的键
+func sortedSchemaNames(schemas map[string]*schema.CompiledSchema) []string {
+	names := make([]string, 0, len(schemas))
+	for name := range schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,94 @@
+package validator
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/songzhibin97/jsonschema-validator/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeywordOrderMatchesAcrossEntryPoints(t *testing.T) {
+	schemaJSON := `{"type": "string", "pattern": "^[a-z]+$", "minLength": 5, "trimmed": true}`
+	var schemaMap map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(schemaJSON), &schemaMap))
+
+	v := New()
+	compiled, err := v.ValidateJSON(`" AB "`, schemaJSON)
+	assert.NoError(t, err)
+	mapped, err := v.ValidateWithSchema(" AB ", schemaMap, "$")
+	assert.NoError(t, err)
+
+	tags := func(result *ValidationResult) []string {
+		out := make([]string, 0, len(result.Errors))
+		for _, e := range result.Errors {
+			out = append(out, e.Tag)
+		}
+		return out
+	}
+	assert.Equal(t, []string{"minLength", "pattern", "trimmed"}, tags(compiled))
+	assert.Equal(t, tags(compiled), tags(mapped))
+
+	// 两个入口都在 enum 失败后跳过其余约束
+	schemaMap["enum"] = []interface{}{"abcdef"}
+	mapped, err = v.ValidateWithSchema(" AB ", schemaMap, "$")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"enum"}, tags(mapped))
+	compiled, err = v.ValidateJSON(`" AB "`, `{"type": "string", "pattern": "^[a-z]+$", "minLength": 5, "trimmed": true, "enum": ["abcdef"]}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"enum"}, tags(compiled))
+}
+
+func TestFirstErrorMatchesAcrossEntryPoints(t *testing.T) {
+	schemaJSON := `{"type": "object", "required": ["a"], "properties": {"b": {"type": "string"}}}`
+	var schemaMap map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(schemaJSON), &schemaMap))
+
+	tests := []struct {
+		name      string
+		jsonData  string
+		value     interface{}
+		expectTag string
+	}{
+		{"Non-object", `"str"`, "str", "type"},
+		{"Missing property", `{"b": 1}`, map[string]interface{}{"b": float64(1)}, "required"},
+	}
+
+	v := New(WithStopOnFirstError(true))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiled, err := v.ValidateJSON(tt.jsonData, schemaJSON)
+			assert.NoError(t, err)
+			mapped, err := v.ValidateWithSchema(tt.value, schemaMap, "$")
+			assert.NoError(t, err)
+			if assert.Len(t, compiled.Errors, 1) && assert.Len(t, mapped.Errors, 1) {
+				assert.Equal(t, tt.expectTag, compiled.Errors[0].Tag)
+				assert.Equal(t, compiled.Errors[0].Tag, mapped.Errors[0].Tag)
+				assert.Equal(t, compiled.Errors[0].Path, mapped.Errors[0].Path)
+				assert.Equal(t, compiled.Errors[0].Message, mapped.Errors[0].Message)
+			}
+		})
+	}
+}
+
+func TestVarStableErrorOrdering(t *testing.T) {
+	v := New()
+	for i := 0; i < 20; i++ {
+		err := v.Var("AB", "minLength=3,pattern=^[a-z]+$")
+		ve, ok := err.(errors.ValidationErrors)
+		if assert.True(t, ok) && assert.Len(t, ve, 2) {
+			assert.Equal(t, "minLength", ve[0].Tag)
+			assert.Equal(t, "pattern", ve[1].Tag)
+		}
+	}
+
+	// 开启 StopOnFirstError 时总是报告同一个错误
+	v = New(WithStopOnFirstError(true))
+	for i := 0; i < 20; i++ {
+		err := v.Var("AB", "pattern=^[a-z]+$,minLength=3")
+		ve, ok := err.(errors.ValidationErrors)
+		if assert.True(t, ok) && assert.Len(t, ve, 1) {
+			assert.Equal(t, "minLength", ve[0].Tag)
+		}
+	}
+}
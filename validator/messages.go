@@ -0,0 +1,77 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/songzhibin97/jsonschema-validator/errors"
+)
+
+// renderMessage 使用错误字段替换消息模板中的占位符
+func renderMessage(template string, e errors.ValidationError) string {
+	value := ""
+	if e.Value != nil {
+		value = fmt.Sprintf("%v", e.Value)
+	}
+	replacer := strings.NewReplacer(
+		"{path}", e.Path,
+		"{param}", e.Param,
+		"{value}", value,
+		"{tag}", e.Tag,
+	)
+	return replacer.Replace(template)
+}
+
+// applyMessages 按标签使用自定义消息覆盖错误消息，并在设置了翻译函数时进行翻译
+func (v *Validator) applyMessages(errs []errors.ValidationError) {
+	if len(v.opts.Messages) == 0 && v.translator == nil {
+		return
+	}
+	for i := range errs {
+		if template, ok := v.opts.Messages[errs[i].Tag]; ok {
+			errs[i].Message = renderMessage(template, errs[i])
+		}
+		if v.translator != nil {
+			if msg := v.translator(errs[i]); msg != "" {
+				errs[i].Message = msg
+			}
+		}
+	}
+}
+
+// finalizeResult 在返回验证结果前处理错误消息，出现非验证错误时仍返回已收集的部分结果
+func (v *Validator) finalizeResult(result *ValidationResult, err error) (*ValidationResult, error) {
+	if result != nil {
+		v.applyMessages(result.Errors)
+	}
+	if err != nil {
+		// 验证未完成，部分结果不能视为通过
+		if result != nil {
+			result.Valid = false
+		}
+		return result, v.finalizeError(err)
+	}
+	return result, nil
+}
+
+// finalizeError 在返回错误前处理错误消息
+func (v *Validator) finalizeError(err error) error {
+	switch e := err.(type) {
+	case errors.ValidationErrors:
+		v.applyMessages(e)
+		return v.formatErrors(e)
+	case *errors.ValidationError:
+		errs := []errors.ValidationError{*e}
+		v.applyMessages(errs)
+		e.Message = errs[0].Message
+	}
+	return err
+}
+
+// formatErrors 非默认的详细格式时包装错误，使 Error() 按配置的格式输出
+func (v *Validator) formatErrors(errs errors.ValidationErrors) error {
+	if v.opts.ErrorFormattingMode != errors.FormattingModeDetailed {
+		return &errors.FormattedErrors{Errors: errs, Mode: v.opts.ErrorFormattingMode}
+	}
+	return errs
+}
@@ -0,0 +1,80 @@
+package validator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/songzhibin97/jsonschema-validator/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithMessages(t *testing.T) {
+	v := New(WithMessages(map[string]string{
+		"required": "{path} is mandatory",
+		"minimum":  "{path} must be at least {param}, got {value}",
+	}))
+
+	type User struct {
+		Name string `validate:"required"`
+		Age  int    `validate:"minimum=18"`
+	}
+
+	err := v.Struct(User{Age: 10})
+	if assert.Error(t, err) {
+		ve, ok := err.(errors.ValidationErrors)
+		if assert.True(t, ok) && assert.Len(t, ve, 2) {
+			assert.Equal(t, "Name is mandatory", ve[0].Message)
+			assert.Equal(t, "Age must be at least 18, got 10", ve[1].Message)
+		}
+	}
+
+	result, err := v.ValidateJSON(`{}`, `{"type":"object","required":["email"]}`)
+	assert.NoError(t, err)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "$.email is mandatory", result.Errors[0].Message)
+	}
+
+	// 未配置模板的标签保持默认消息
+	result, err = v.ValidateWithSchema(123, map[string]interface{}{"type": "string"}, "root")
+	assert.NoError(t, err)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Contains(t, result.Errors[0].Message, "expected string")
+	}
+}
+
+func TestRenderMessage(t *testing.T) {
+	e := errors.ValidationError{Path: "root.age", Tag: "maximum", Param: "99", Value: 120}
+	assert.Equal(t, "maximum: root.age=120 > 99", renderMessage("{tag}: {path}={value} > {param}", e))
+	assert.Equal(t, "no placeholders", renderMessage("no placeholders", e))
+}
+
+func TestSetTranslator(t *testing.T) {
+	v := New()
+	v.SetTranslator(func(err errors.ValidationError) string {
+		if err.Tag == "minimum" {
+			return fmt.Sprintf("%s doit être supérieur ou égal à %s", err.Path, err.Param)
+		}
+		return ""
+	})
+
+	type Product struct {
+		Price int `validate:"minimum=10"`
+		Name  int `validate:"type=string"`
+	}
+
+	err := v.Struct(Product{Price: 5, Name: 1})
+	if assert.Error(t, err) {
+		ve, ok := err.(errors.ValidationErrors)
+		if assert.True(t, ok) && assert.Len(t, ve, 2) {
+			assert.Equal(t, "Price doit être supérieur ou égal à 10", ve[0].Message)
+			// 翻译函数返回空字符串时保留默认消息
+			assert.Contains(t, ve[1].Message, "expected string")
+		}
+	}
+
+	result, err := v.ValidateJSON(`{"price":1}`, `{"type":"object","properties":{"price":{"minimum":10}}}`)
+	assert.NoError(t, err)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "$.price doit être supérieur ou égal à 10", result.Errors[0].Message)
+	}
+}
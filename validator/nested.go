@@ -0,0 +1,81 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/songzhibin97/jsonschema-validator/errors"
+)
+
+// visitKey 标识递归验证中已进入的结构体指针
+type visitKey struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// hasNestedStruct 检查类型是否为结构体、结构体指针，或元素为结构体（指针）的切片和数组
+func hasNestedStruct(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
+// validateNested 递归验证字段中的嵌套结构体，返回的错误路径以 path 为前缀
+func (v *Validator) validateNested(ctx context.Context, value reflect.Value, path string, visited map[visitKey]bool) ([]errors.ValidationError, error) {
+	if !value.CanInterface() || !hasNestedStruct(value.Type()) {
+		return nil, nil
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		return v.nestedStructErrors(ctx, value, path, visited)
+	case reflect.Ptr:
+		if value.IsNil() {
+			return nil, nil
+		}
+		return v.nestedStructErrors(ctx, value, path, visited)
+	case reflect.Slice, reflect.Array:
+		var errs []errors.ValidationError
+		for i := 0; i < value.Len(); i++ {
+			elemErrs, err := v.validateNested(ctx, value.Index(i), fmt.Sprintf("%s[%d]", path, i), visited)
+			if err != nil {
+				return nil, err
+			}
+			errs = append(errs, elemErrs...)
+			if len(errs) > 0 && v.opts.StopOnFirstError {
+				break
+			}
+		}
+		return errs, nil
+	}
+	return nil, nil
+}
+
+// nestedStructErrors 验证单个嵌套结构体并为错误路径添加前缀
+func (v *Validator) nestedStructErrors(ctx context.Context, s reflect.Value, path string, visited map[visitKey]bool) ([]errors.ValidationError, error) {
+	err := v.validateStruct(ctx, s, visited)
+	if err == nil {
+		return nil, nil
+	}
+	ve, ok := err.(errors.ValidationErrors)
+	if !ok {
+		return nil, &errors.ValidationError{
+			Path:    path,
+			Message: fmt.Sprintf("nested struct validation error: %v", err),
+			Tag:     "struct_validation",
+			Value:   s.Interface(),
+		}
+	}
+	errs := make([]errors.ValidationError, 0, len(ve))
+	for _, e := range ve {
+		e.Path = path + "." + e.Path
+		errs = append(errs, e)
+	}
+	return errs, nil
+}
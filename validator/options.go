@@ -1,6 +1,9 @@
 package validator
 
 import (
+	"encoding/json"
+	"time"
+
 	"github.com/songzhibin97/jsonschema-validator/errors"
 	"github.com/songzhibin97/jsonschema-validator/schema"
 )
@@ -22,11 +25,68 @@ type Options struct {
 	// RecursiveValidation 是否递归验证嵌套结构
 	RecursiveValidation bool
 
+	// UntaggedNestedValidation 递归验证时是否也进入未声明验证标签的嵌套结构体字段
+	UntaggedNestedValidation bool
+
 	// StopOnFirstError 是否在第一个错误时停止验证
 	StopOnFirstError bool
 
+	// ErrorLimit 限制收集的错误数量，达到上限后停止验证，0 表示不限制
+	ErrorLimit int
+
 	// AllowUnknownFields 是否允许数据中包含schema中未定义的字段
 	AllowUnknownFields bool
+
+	// PreserveKeyOrder 是否在验证JSON时记录对象键的原始顺序，供 keyOrder 关键字使用
+	PreserveKeyOrder bool
+
+	// ByteLength 是否按字节而非 Unicode 码点计算字符串长度
+	ByteLength bool
+
+	// FormatAssertion 是否对已知格式执行 format 断言，关闭时 format 仅作为注解
+	FormatAssertion bool
+
+	// UnknownFormatAssertion 非宽松模式下遇到未知格式时是否报错
+	UnknownFormatAssertion bool
+
+	// CollectAnnotations 是否在验证结果中收集 title/description/default 等注解
+	CollectAnnotations bool
+
+	// ImplicitObjectType 是否将定义了 properties 但未声明 type 的schema视为要求对象
+	ImplicitObjectType bool
+
+	// Coverage 是否在验证结果中记录实际执行过的schema关键字
+	Coverage bool
+
+	// CoerceTypes 是否在类型检查时接受字符串形式的整数、数字和布尔值
+	CoerceTypes bool
+
+	// StrictTypes 是否要求 integer 类型的JSON数值在字面上是整数（不含小数点和指数）
+	StrictTypes bool
+
+	// NumberParser 非空时 ValidateJSON 将数值交给该函数解码，例如解码为十进制金额类型
+	NumberParser func(json.Number) (interface{}, error)
+
+	// MaxPatternLength 限制schema中 pattern/patternProperties 模式字符串的最大长度，0 表示不限制
+	MaxPatternLength int
+
+	// MaxDocumentBytes 限制待验证JSON文档的最大字节数，0 表示不限制
+	MaxDocumentBytes int64
+
+	// JSONFieldNames 结构体验证的错误路径是否使用 json 标签中的字段名
+	JSONFieldNames bool
+
+	// Clock 返回 pastDateTime/futureDateTime 比较时使用的当前时间，为空时使用 time.Now
+	Clock func() time.Time
+
+	// RootPath 错误路径的根标记，为空时JSON验证使用 "$"，结构体验证不加前缀
+	RootPath string
+
+	// Messages 按验证标签自定义错误消息模板，支持 {path}、{param}、{value}、{tag} 占位符
+	Messages map[string]string
+
+	// ContextValues 写入每次验证上下文的键值，调用方上下文中已有的同名键优先
+	ContextValues map[interface{}]interface{}
 }
 
 // Option 是用于配置验证器的函数选项
@@ -67,6 +127,13 @@ func WithRecursiveValidation(enable bool) Option {
 	}
 }
 
+// WithUntaggedNestedValidation 设置递归验证时是否也进入未声明验证标签的嵌套结构体字段，需要同时启用 WithRecursiveValidation
+func WithUntaggedNestedValidation(enable bool) Option {
+	return func(o *Options) {
+		o.UntaggedNestedValidation = enable
+	}
+}
+
 // WithStopOnFirstError 设置是否在第一个错误时停止验证
 func WithStopOnFirstError(enable bool) Option {
 	return func(o *Options) {
@@ -80,3 +147,139 @@ func WithAllowUnknownFields(allow bool) Option {
 		o.AllowUnknownFields = allow
 	}
 }
+
+// WithMessages 设置按验证标签自定义的错误消息模板
+func WithMessages(messages map[string]string) Option {
+	return func(o *Options) {
+		o.Messages = messages
+	}
+}
+
+// WithPreserveKeyOrder 设置是否记录JSON对象键的原始顺序
+func WithPreserveKeyOrder(enable bool) Option {
+	return func(o *Options) {
+		o.PreserveKeyOrder = enable
+	}
+}
+
+// WithByteLength 设置是否按字节计算 minLength/maxLength 的字符串长度
+func WithByteLength(enable bool) Option {
+	return func(o *Options) {
+		o.ByteLength = enable
+	}
+}
+
+// WithFormatAssertion 设置是否对已知格式执行 format 断言
+func WithFormatAssertion(enable bool) Option {
+	return func(o *Options) {
+		o.FormatAssertion = enable
+	}
+}
+
+// WithUnknownFormatAssertion 设置非宽松模式下未知格式是否报错
+func WithUnknownFormatAssertion(enable bool) Option {
+	return func(o *Options) {
+		o.UnknownFormatAssertion = enable
+	}
+}
+
+// WithCollectAnnotations 设置是否在验证结果中收集注解
+func WithCollectAnnotations(enable bool) Option {
+	return func(o *Options) {
+		o.CollectAnnotations = enable
+	}
+}
+
+// WithImplicitObjectType 设置定义了 properties 但未声明 type 的schema是否要求值为对象，
+// 默认按规范对非对象值跳过 properties
+func WithImplicitObjectType(enable bool) Option {
+	return func(o *Options) {
+		o.ImplicitObjectType = enable
+	}
+}
+
+// WithErrorLimit 设置最多收集的错误数量，达到上限后停止验证；与 WithStopOnFirstError 不同，可以返回至多 n 个错误
+func WithErrorLimit(n int) Option {
+	return func(o *Options) {
+		o.ErrorLimit = n
+	}
+}
+
+// WithCoverage 设置是否在 ValidationResult.Coverage 中记录实际执行过的关键字位置，
+// 便于发现从未生效的约束
+func WithCoverage(enable bool) Option {
+	return func(o *Options) {
+		o.Coverage = enable
+	}
+}
+
+// WithJSONFieldNames 设置结构体验证的错误路径是否使用 json 标签中的字段名
+func WithJSONFieldNames(enable bool) Option {
+	return func(o *Options) {
+		o.JSONFieldNames = enable
+	}
+}
+
+// WithMaxDocumentBytes 设置待验证JSON文档的最大字节数，超出时在解码前拒绝
+func WithMaxDocumentBytes(n int64) Option {
+	return func(o *Options) {
+		o.MaxDocumentBytes = n
+	}
+}
+
+// WithMaxPatternLength 设置schema中模式字符串的最大长度，编译时拒绝超长的模式以限制用户提供的正则表达式的开销
+func WithMaxPatternLength(n int) Option {
+	return func(o *Options) {
+		o.MaxPatternLength = n
+	}
+}
+
+// WithStrictTypes 设置 integer 类型是否要求JSON数值的字面形式为整数：开启后 ValidateJSON 按 json.Number 解码，
+// 30 是整数而 30.0、3e1 不是
+func WithStrictTypes(enable bool) Option {
+	return func(o *Options) {
+		o.StrictTypes = enable
+	}
+}
+
+// WithNumberParser 设置 ValidateJSON 解码数值的函数，避免金额等数值经 float64 丢失精度；
+// 解码出的类型满足 number 类型检查，minimum/maximum 等数值规则和 compare 通过已注册的比较器（ge、le、gt、lt 等）比较该类型，
+// enum、const、uniqueItems 通过 eq 比较器判断相等，因此需要同时用 RegisterComparator 注册支持该类型的比较器；
+// multipleOf 按该类型的十进制字符串形式（fmt.Sprint）精确计算
+func WithNumberParser(parse func(json.Number) (interface{}, error)) Option {
+	return func(o *Options) {
+		o.NumberParser = parse
+	}
+}
+
+// WithCoerceTypes 设置类型检查时是否接受字符串形式的整数、数字和布尔值，适合验证查询参数和表单数据
+func WithCoerceTypes(enable bool) Option {
+	return func(o *Options) {
+		o.CoerceTypes = enable
+	}
+}
+
+// WithRootPath 设置所有入口的错误路径统一使用的根标记（默认 "$"），结构体验证的路径也以该标记开头
+func WithRootPath(token string) Option {
+	return func(o *Options) {
+		o.RootPath = token
+	}
+}
+
+// WithClock 设置 pastDateTime/futureDateTime 比较时使用的时钟，便于测试使用固定时间
+func WithClock(now func() time.Time) Option {
+	return func(o *Options) {
+		o.Clock = now
+	}
+}
+
+// WithContextValue 设置写入每次验证上下文的键值，自定义规则通过 ctx.Value(key) 读取，
+// requireContext 关键字可以声明schema依赖的键；ValidateJSONCtx 传入的上下文中已有同名键时以调用方的值为准
+func WithContextValue(key, val interface{}) Option {
+	return func(o *Options) {
+		if o.ContextValues == nil {
+			o.ContextValues = make(map[interface{}]interface{})
+		}
+		o.ContextValues[key] = val
+	}
+}
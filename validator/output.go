@@ -0,0 +1,95 @@
+package validator
+
+import (
+	"context"
+	"strings"
+
+	"github.com/songzhibin97/jsonschema-validator/errors"
+)
+
+// ToOutputFormat 按 JSON Schema 规范的 "basic" 输出格式返回验证结果：
+// 顶层 valid 布尔值和扁平的错误列表，每个错误包含 keywordLocation、instanceLocation（JSON Pointer）和 error
+func (r *ValidationResult) ToOutputFormat() map[string]interface{} {
+	output := map[string]interface{}{"valid": r.Valid}
+	if len(r.Errors) == 0 {
+		return output
+	}
+	units := make([]map[string]interface{}, 0, len(r.Errors))
+	for _, e := range r.Errors {
+		units = append(units, map[string]interface{}{
+			"keywordLocation":  e.SchemaPath,
+			"instanceLocation": toJSONPointer(e.Path, r.rootPath),
+			"error":            e.Message,
+		})
+	}
+	output["errors"] = units
+	return output
+}
+
+// toJSONPointer 将 "$.user.tags[0]" 形式的错误路径转换为 "/user/tags/0" 形式的 JSON Pointer，
+// 先去掉验证时使用的根路径标记 root（如 WithRootPath 或 ValidateJSONAtPath 设置的 "body.user"），为空时按 "$" 处理
+func toJSONPointer(path string, root string) string {
+	if root == "" {
+		root = "$"
+	}
+	if path == root {
+		return ""
+	}
+	if strings.HasPrefix(path, root+".") || strings.HasPrefix(path, root+"[") {
+		path = path[len(root):]
+	}
+	path = strings.TrimPrefix(path, ".")
+	if path == "" {
+		return ""
+	}
+
+	var sb strings.Builder
+	for _, segment := range strings.Split(path, ".") {
+		// 拆分数组下标，例如 tags[0][1] -> tags, 0, 1
+		name := segment
+		var indexes []string
+		if i := strings.IndexByte(segment, '['); i >= 0 && strings.HasSuffix(segment, "]") {
+			name = segment[:i]
+			indexes = strings.Split(strings.TrimSuffix(segment[i+1:], "]"), "][")
+		}
+		if name != "" {
+			sb.WriteString("/" + escapeJSONPointer(name))
+		}
+		for _, index := range indexes {
+			sb.WriteString("/" + index)
+		}
+	}
+	return sb.String()
+}
+
+// jsonPointerEscaper 按 RFC 6901 转义 JSON Pointer 中的引用标记
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// escapeJSONPointer 转义 JSON Pointer 中的单个引用标记
+func escapeJSONPointer(token string) string {
+	return jsonPointerEscaper.Replace(token)
+}
+
+// schemaPathFrom 返回上下文中当前schema的 JSON Pointer，根schema为空字符串
+func schemaPathFrom(ctx context.Context) string {
+	schemaPath, _ := ctx.Value("schemaPath").(string)
+	return schemaPath
+}
+
+// withSchemaPath 在上下文的schema路径后追加引用标记，用于进入子schema
+func withSchemaPath(ctx context.Context, tokens ...string) context.Context {
+	schemaPath := schemaPathFrom(ctx)
+	for _, token := range tokens {
+		schemaPath += "/" + escapeJSONPointer(token)
+	}
+	return context.WithValue(ctx, "schemaPath", schemaPath)
+}
+
+// setSchemaPath 为尚未记录schema路径的错误设置 schemaPath
+func setSchemaPath(errs []errors.ValidationError, schemaPath string) {
+	for i := range errs {
+		if errs[i].SchemaPath == "" {
+			errs[i].SchemaPath = schemaPath
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToOutputFormat(t *testing.T) {
+	v := New()
+	result, err := v.ValidateJSON(`{"name": "ab", "tags": [1, "x"]}`,
+		`{"type": "object", "properties": {"name": {"type": "string", "minLength": 3}, "tags": {"type": "array", "items": {"type": "string"}}}}`)
+	assert.NoError(t, err)
+
+	output := result.ToOutputFormat()
+	assert.Equal(t, false, output["valid"])
+	units, ok := output["errors"].([]map[string]interface{})
+	if assert.True(t, ok) && assert.Len(t, units, 2) {
+		locations := map[string]map[string]interface{}{}
+		for _, unit := range units {
+			assert.Len(t, unit, 3)
+			locations[unit["instanceLocation"].(string)] = unit
+		}
+		assert.Equal(t, "length less than minimum 3", locations["/name"]["error"])
+		assert.Equal(t, "/properties/name/minLength", locations["/name"]["keywordLocation"])
+		assert.Equal(t, "/properties/tags/items/type", locations["/tags/0"]["keywordLocation"])
+	}
+
+	result, err = v.ValidateJSON(`{"name": "abc"}`, `{"type": "object"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"valid": true}, result.ToOutputFormat())
+}
+
+func TestToJSONPointer(t *testing.T) {
+	tests := []struct {
+		path     string
+		root     string
+		expected string
+	}{
+		{"$", "", ""},
+		{"$.user.name", "", "/user/name"},
+		{"$.tags[0]", "$", "/tags/0"},
+		{"$[1].a", "$", "/1/a"},
+		{"$.matrix[0][2]", "$", "/matrix/0/2"},
+		{"$.a~b/c", "$", "/a~0b~1c"},
+		{"Address.City", "", "/Address/City"},
+		{"body.user.b", "body.user", "/b"},
+		{"body.user[0]", "body.user", "/0"},
+		{"body.user", "body.user", ""},
+		{"body.username", "body.user", "/body/username"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.expected, toJSONPointer(tt.path, tt.root))
+		})
+	}
+}
+
+func TestToOutputFormatAtPath(t *testing.T) {
+	result, err := New().ValidateJSONAtPath(`{"a": 1, "b": 2}`, `{"properties": {"b": {"type": "string"}}}`, "body.user")
+	assert.NoError(t, err)
+	units, ok := result.ToOutputFormat()["errors"].([]map[string]interface{})
+	if assert.True(t, ok) && assert.Len(t, units, 1) {
+		assert.Equal(t, "/b", units[0]["instanceLocation"])
+	}
+}
+
+func TestToOutputFormatWithRootPath(t *testing.T) {
+	v := New(WithRootPath("root"))
+	result, err := v.ValidateJSON(`{"a": {"b": 1}}`, `{"properties": {"a": {"properties": {"b": {"type": "string"}}}}}`)
+	assert.NoError(t, err)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "root.a.b", result.Errors[0].Path)
+	}
+	units, ok := result.ToOutputFormat()["errors"].([]map[string]interface{})
+	if assert.True(t, ok) && assert.Len(t, units, 1) {
+		assert.Equal(t, "/a/b", units[0]["instanceLocation"])
+	}
+}
+
+func TestSchemaPath(t *testing.T) {
+	v := New()
+	schemaJSON := `{
+		"type": "object",
+		"required": ["id"],
+		"properties": {
+			"user": {"type": "object", "properties": {"age": {"type": "integer", "minimum": 18}}},
+			"pairs": {"type": "array", "prefixItems": [{"type": "string"}]}
+		},
+		"patternProperties": {"^x/": {"type": "boolean"}}
+	}`
+
+	result, err := v.ValidateJSON(`{"user": {"age": 10}, "pairs": [1], "x/flag": 1}`, schemaJSON)
+	assert.NoError(t, err)
+	schemaPaths := map[string]string{}
+	for _, e := range result.Errors {
+		schemaPaths[e.Path] = e.SchemaPath
+	}
+	assert.Equal(t, map[string]string{
+		"$.id":       "/required",
+		"$.user.age": "/properties/user/properties/age/minimum",
+		"$.pairs[0]": "/properties/pairs/prefixItems/0/type",
+		"$.x/flag":   "/patternProperties/^x~1/type",
+	}, schemaPaths)
+}
@@ -1,10 +1,15 @@
 package validator
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -27,15 +32,19 @@ type Validator struct {
 	tagNameFunc        func(field reflect.StructField) string
 	customTypeFunc     func(field reflect.Value) interface{}
 	customValidateFunc func(ctx context.Context, value interface{}, path string) (bool, error)
+	translator         func(err errors.ValidationError) string
+	formats            *rules2.FormatRegistry
 	cache              *sync.Map
 }
 
 // New 创建一个新的验证器实例
 func New(opts ...Option) *Validator {
 	options := &Options{
-		TagName:             "validate",
-		ValidationMode:      schema.ModeStrict,
-		ErrorFormattingMode: errors.FormattingModeDetailed,
+		TagName:                "validate",
+		ValidationMode:         schema.ModeStrict,
+		ErrorFormattingMode:    errors.FormattingModeDetailed,
+		FormatAssertion:        true,
+		UnknownFormatAssertion: true,
 	}
 	for _, opt := range opts {
 		opt(options)
@@ -46,6 +55,7 @@ func New(opts ...Option) *Validator {
 		validators:  make(map[string]rules2.RuleFunc),
 		comparators: make(map[string]comparators.CompareFunc),
 		cache:       &sync.Map{},
+		formats:     rules2.NewFormatRegistry(),
 	}
 
 	// 注册内置规则和比较器
@@ -130,6 +140,16 @@ func (v *Validator) SetCustomValidateFunc(fn func(ctx context.Context, value int
 	v.customValidateFunc = fn
 }
 
+// RegisterFormat 为当前验证器实例注册格式验证函数，不影响其他实例，同名时覆盖全局格式
+func (v *Validator) RegisterFormat(name string, fn func(string) bool) {
+	v.formats.Register(name, fn)
+}
+
+// SetTranslator 设置错误消息翻译函数，返回空字符串时保留默认消息
+func (v *Validator) SetTranslator(fn func(err errors.ValidationError) string) {
+	v.translator = fn
+}
+
 // Struct 验证结构体
 func (v *Validator) Struct(s interface{}) error {
 	return v.StructCtx(context.Background(), s)
@@ -137,8 +157,50 @@ func (v *Validator) Struct(s interface{}) error {
 
 // StructCtx 带上下文的结构体验证
 func (v *Validator) StructCtx(ctx context.Context, s interface{}) error {
-	val := reflect.ValueOf(s)
+	return v.structAtPath(ctx, s, v.opts.RootPath)
+}
+
+// StructAtPath 验证结构体，错误路径以 rootPath 为前缀（例如 "body.user.Name"）
+func (v *Validator) StructAtPath(s interface{}, rootPath string) error {
+	return v.structAtPath(context.Background(), s, rootPath)
+}
+
+// structAtPath 验证结构体并为错误路径加上 rootPath 前缀，rootPath 为空时保持字段路径不变
+func (v *Validator) structAtPath(ctx context.Context, s interface{}, rootPath string) error {
+	err := v.structCtx(ctx, s)
+	if ve, ok := err.(errors.ValidationErrors); ok && rootPath != "" {
+		for i := range ve {
+			ve[i].Path = rootPath + "." + ve[i].Path
+		}
+	}
+	if err != nil {
+		return v.finalizeError(err)
+	}
+	return nil
+}
+
+// structCtx 执行结构体验证
+func (v *Validator) structCtx(ctx context.Context, s interface{}) error {
+	return v.validateStruct(ctx, reflect.ValueOf(s), make(map[visitKey]bool))
+}
+
+// validateStruct 验证结构体，visited 记录当前递归路径上已进入的结构体指针以避免循环引用导致无限递归
+// 参数使用 reflect.Value，使未导出的嵌入结构体中的导出字段也能被验证
+func (v *Validator) validateStruct(ctx context.Context, val reflect.Value, visited map[visitKey]bool) error {
+	var s interface{}
+	if val.IsValid() && val.CanInterface() {
+		s = val.Interface()
+	}
 	if val.Kind() == reflect.Ptr {
+		if !val.IsNil() {
+			key := visitKey{ptr: val.Pointer(), typ: val.Type()}
+			if visited[key] {
+				// 循环引用：该结构体已在当前递归路径上验证
+				return nil
+			}
+			visited[key] = true
+			defer delete(visited, key)
+		}
 		val = val.Elem()
 	}
 	if val.Kind() != reflect.Struct {
@@ -162,7 +224,39 @@ func (v *Validator) StructCtx(ctx context.Context, s interface{}) error {
 		if v.tagNameFunc != nil {
 			tag = v.tagNameFunc(field)
 		}
+		// 匿名嵌入的结构体字段按外层结构体的字段验证，错误路径不加前缀
+		if tag == "" && field.Anonymous && hasNestedStruct(field.Type) && value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+			if value.Kind() == reflect.Ptr && value.IsNil() {
+				continue
+			}
+			if err := v.validateStruct(ctx, value, visited); err != nil {
+				ve, ok := err.(errors.ValidationErrors)
+				if !ok {
+					return err
+				}
+				result.Valid = false
+				result.Errors = append(result.Errors, ve...)
+				if v.opts.StopOnFirstError {
+					return errors.ValidationErrors(result.Errors)
+				}
+			}
+			continue
+		}
 		if tag == "" {
+			// 未声明标签的嵌套结构体字段仅在启用 UntaggedNestedValidation 时检查
+			if v.opts.RecursiveValidation && v.opts.UntaggedNestedValidation {
+				nestedErrs, err := v.validateNested(ctx, value, v.fieldPathName(field), visited)
+				if err != nil {
+					return err
+				}
+				if len(nestedErrs) > 0 {
+					result.Valid = false
+					result.Errors = append(result.Errors, nestedErrs...)
+					if v.opts.StopOnFirstError {
+						return errors.ValidationErrors(result.Errors)
+					}
+				}
+			}
 			continue
 		}
 
@@ -171,8 +265,13 @@ func (v *Validator) StructCtx(ctx context.Context, s interface{}) error {
 			continue
 		}
 
-		path := field.Name
-		fieldValue := value.Interface()
+		path := v.fieldPathName(field)
+		// 非空指针字段按其指向的值验证
+		elem := value
+		for elem.Kind() == reflect.Ptr && !elem.IsNil() {
+			elem = elem.Elem()
+		}
+		fieldValue := elem.Interface()
 		if v.customTypeFunc != nil {
 			fieldValue = v.customTypeFunc(value)
 		}
@@ -205,7 +304,12 @@ func (v *Validator) StructCtx(ctx context.Context, s interface{}) error {
 
 		// 处理 required
 		if _, isRequired := schemaMap["required"]; isRequired {
-			if isZero(value) {
+			// 非空的结构体指针即视为已设置，其字段由递归验证检查
+			missing := isZero(elem)
+			if value.Kind() == reflect.Ptr && elem.Kind() == reflect.Struct {
+				missing = false
+			}
+			if missing {
 				result.Valid = false
 				result.Errors = append(result.Errors, errors.ValidationError{
 					Path:    path,
@@ -220,32 +324,45 @@ func (v *Validator) StructCtx(ctx context.Context, s interface{}) error {
 			delete(schemaMap, "required")
 		}
 
-		// 递归验证嵌套结构体
-		if v.opts.RecursiveValidation && value.Kind() == reflect.Struct {
-			if err := v.StructCtx(ctx, fieldValue); err != nil {
-				if ve, ok := err.(errors.ValidationErrors); ok {
-					for _, e := range ve {
-						e.Path = path + "." + e.Path
-						result.Errors = append(result.Errors, e)
-					}
-					result.Valid = false
-					if v.opts.StopOnFirstError {
-						return errors.ValidationErrors(result.Errors)
-					}
-				} else {
-					return &errors.ValidationError{
-						Path:    path,
-						Message: fmt.Sprintf("nested struct validation error: %v", err),
-						Tag:     "struct_validation",
-						Value:   fieldValue,
-					}
+		// 空指针视为未设置的可选字段，不再应用其他规则
+		if elem.Kind() == reflect.Ptr {
+			continue
+		}
+
+		// 处理跨字段比较
+		crossErrs, err := v.validateCrossFields(val, fieldValue, schemaMap, path)
+		if err != nil {
+			return err
+		}
+		if len(crossErrs) > 0 {
+			result.Valid = false
+			result.Errors = append(result.Errors, crossErrs...)
+			if v.opts.StopOnFirstError {
+				return errors.ValidationErrors(result.Errors)
+			}
+		}
+
+		// 递归验证嵌套结构体（包括结构体指针和结构体切片）
+		if v.opts.RecursiveValidation && hasNestedStruct(value.Type()) {
+			nestedErrs, err := v.validateNested(ctx, value, path, visited)
+			if err != nil {
+				return err
+			}
+			if len(nestedErrs) > 0 {
+				result.Valid = false
+				result.Errors = append(result.Errors, nestedErrs...)
+				if v.opts.StopOnFirstError {
+					return errors.ValidationErrors(result.Errors)
 				}
 			}
-			continue
+			// 切片和数组继续应用自身的规则
+			if value.Kind() == reflect.Struct || value.Kind() == reflect.Ptr {
+				continue
+			}
 		}
 
 		// 验证其他规则
-		fieldResult, err := v.ValidateWithSchema(fieldValue, schemaMap, path)
+		fieldResult, err := v.validateWithSchema(fieldValue, schemaMap, path)
 		if err != nil {
 			return err
 		}
@@ -280,283 +397,1001 @@ func (v *Validator) VarCtx(ctx context.Context, field interface{}, tag string) e
 		return err
 	}
 	if !result.Valid {
-		return errors.ValidationErrors(result.Errors)
+		return v.formatErrors(result.Errors)
 	}
 	return nil
 }
 
 // ValidateJSON 验证JSON字符串是否符合指定的schema
 func (v *Validator) ValidateJSON(jsonData string, schemaJSON string) (*ValidationResult, error) {
-	var data interface{}
-	if err := json.Unmarshal([]byte(jsonData), &data); err != nil {
+	return v.validateJSON(context.Background(), jsonData, schemaJSON, v.rootPath())
+}
+
+// ValidateJSONCtx 使用调用方提供的上下文验证JSON字符串，自定义规则可以从上下文中读取调用方写入的值；
+// 上下文被取消或超时时返回 ctx.Err()
+func (v *Validator) ValidateJSONCtx(ctx context.Context, jsonData string, schemaJSON string) (*ValidationResult, error) {
+	return v.validateJSON(ctx, jsonData, schemaJSON, v.rootPath())
+}
+
+// Valid 验证JSON字符串并只返回是否有效，遇到第一个错误即停止验证，适合不关心错误详情的调用方
+func (v *Validator) Valid(jsonData string, schemaJSON string) (bool, error) {
+	ctx := context.WithValue(context.Background(), "stopOnFirstError", true)
+	result, err := v.validateJSON(ctx, jsonData, schemaJSON, v.rootPath())
+	if err != nil {
+		return false, err
+	}
+	return result.Valid, nil
+}
+
+// ValidateJSONAtPath 验证JSON字符串，错误路径以 rootPath 而非 "$" 开头，
+// 适合验证位于更大请求中的子文档（例如 rootPath 为 "body.user"）
+func (v *Validator) ValidateJSONAtPath(jsonData string, schemaJSON string, rootPath string) (*ValidationResult, error) {
+	return v.validateJSON(context.Background(), jsonData, schemaJSON, rootPath)
+}
+
+// ValidateReader 从 reader 读取JSON数据并验证，设置了 MaxDocumentBytes 时最多读取限制的字节数
+func (v *Validator) ValidateReader(r io.Reader, schemaJSON string) (*ValidationResult, error) {
+	data, err := v.readDocument(r)
+	if err != nil {
+		return nil, err
+	}
+	return v.ValidateJSON(string(data), schemaJSON)
+}
+
+// readDocument 读取JSON文档，超过 MaxDocumentBytes 时返回错误
+func (v *Validator) readDocument(r io.Reader) ([]byte, error) {
+	max := v.opts.MaxDocumentBytes
+	if max <= 0 {
+		return io.ReadAll(r)
+	}
+	data, err := io.ReadAll(io.LimitReader(r, max+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > max {
+		return nil, v.documentTooLargeError()
+	}
+	return data, nil
+}
+
+// documentTooLargeError 构造文档超出大小限制的错误
+func (v *Validator) documentTooLargeError() error {
+	return fmt.Errorf("JSON document exceeds maximum size of %d bytes", v.opts.MaxDocumentBytes)
+}
+
+// rootPath 返回JSON验证错误路径的根标记，未通过 WithRootPath 设置时为 "$"
+func (v *Validator) rootPath() string {
+	if v.opts.RootPath != "" {
+		return v.opts.RootPath
+	}
+	return "$"
+}
+
+// validateJSON 解码JSON数据并从 rootPath 开始验证
+func (v *Validator) validateJSON(ctx context.Context, jsonData string, schemaJSON string, rootPath string) (*ValidationResult, error) {
+	if v.opts.MaxDocumentBytes > 0 && int64(len(jsonData)) > v.opts.MaxDocumentBytes {
+		return nil, v.documentTooLargeError()
+	}
+
+	data, err := v.decodeJSON(jsonData)
+	if err != nil {
 		return nil, fmt.Errorf("invalid JSON data: %w", err)
 	}
 
+	if v.opts.PreserveKeyOrder {
+		orders, err := decodeKeyOrders(jsonData, data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON data: %w", err)
+		}
+		ctx = context.WithValue(ctx, "keyOrders", orders)
+	}
+	return v.validateValue(ctx, data, schemaJSON, rootPath)
+}
+
+// decodeJSON 解码JSON数据；启用 StrictTypes 时数值解码为 json.Number 以保留字面形式，
+// 设置 NumberParser 时再交给该函数转换
+func (v *Validator) decodeJSON(jsonData string) (interface{}, error) {
+	var data interface{}
+	if !v.opts.StrictTypes && v.opts.NumberParser == nil {
+		err := json.Unmarshal([]byte(jsonData), &data)
+		return data, err
+	}
+	dec := json.NewDecoder(strings.NewReader(jsonData))
+	dec.UseNumber()
+	if err := dec.Decode(&data); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after top-level value")
+	}
+	if v.opts.NumberParser == nil {
+		return data, nil
+	}
+	return parseNumbers(data, v.opts.NumberParser)
+}
+
+// parseNumbers 递归地将解码结果中的 json.Number 交给 parse 转换
+func parseNumbers(value interface{}, parse func(json.Number) (interface{}, error)) (interface{}, error) {
+	switch val := value.(type) {
+	case json.Number:
+		parsed, err := parse(val)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse number %s: %w", val, err)
+		}
+		return parsed, nil
+	case map[string]interface{}:
+		for key, item := range val {
+			parsed, err := parseNumbers(item, parse)
+			if err != nil {
+				return nil, err
+			}
+			val[key] = parsed
+		}
+	case []interface{}:
+		for i, item := range val {
+			parsed, err := parseNumbers(item, parse)
+			if err != nil {
+				return nil, err
+			}
+			val[i] = parsed
+		}
+	}
+	return value, nil
+}
+
+// ValidateJSONFile 读取数据文件和schema文件并进行验证
+func (v *Validator) ValidateJSONFile(dataPath string, schemaPath string) (*ValidationResult, error) {
+	schemaBytes, err := os.ReadFile(schemaPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema file %s: %w", schemaPath, err)
+	}
+	dataFile, err := os.Open(dataPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read data file %s: %w", dataPath, err)
+	}
+	defer dataFile.Close()
+	dataBytes, err := v.readDocument(dataFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read data file %s: %w", dataPath, err)
+	}
+	return v.ValidateJSON(string(dataBytes), string(schemaBytes))
+}
+
+// ValidateValue 验证已解码的Go值是否符合指定的schema，跳过JSON反序列化步骤
+// 值应与 encoding/json 解码的结果一致：对象为 map[string]interface{}，数组为 []interface{}，
+// 数值为 float64 或 json.Number
+func (v *Validator) ValidateValue(value interface{}, schemaJSON string) (*ValidationResult, error) {
+	return v.validateValue(context.Background(), value, schemaJSON, v.rootPath())
+}
+
+// Validate 根据输入类型选择验证方式：string、[]byte 和 json.RawMessage 视为JSON文本；
+// map[string]interface{}、[]interface{} 等已解码的值直接验证；
+// 结构体（及其指针）和其他 map、切片按 json 标签转换为解码后的形式再验证
+func (v *Validator) Validate(input interface{}, schemaJSON string) (*ValidationResult, error) {
+	switch in := input.(type) {
+	case string:
+		return v.ValidateJSON(in, schemaJSON)
+	case []byte:
+		return v.ValidateJSON(string(in), schemaJSON)
+	case json.RawMessage:
+		return v.ValidateJSON(string(in), schemaJSON)
+	case nil, map[string]interface{}, []interface{}, bool, float64, json.Number:
+		return v.ValidateValue(in, schemaJSON)
+	}
+
+	switch reflect.Indirect(reflect.ValueOf(input)).Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		value, err := StructToValue(input)
+		if err != nil {
+			return nil, err
+		}
+		return v.ValidateValue(value, schemaJSON)
+	default:
+		return v.ValidateValue(input, schemaJSON)
+	}
+}
+
+// StructToValue 将结构体（或其他Go值）转换为编译后schema所需的解码形式：
+// 对象为 map[string]interface{}，数组为 []interface{}，数值为 json.Number。
+// 字段名遵循 json 标签，omitempty 和 "-" 的处理与 encoding/json 一致
+func StructToValue(s interface{}) (interface{}, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %T to JSON: %w", s, err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return nil, fmt.Errorf("failed to convert %T to JSON: %w", s, err)
+	}
+	return value, nil
+}
+
+// ValidateAgainst 使用已编译的schema验证值，适合持有编译结果并重复验证多个值
+func (v *Validator) ValidateAgainst(value interface{}, s *schema.Schema) (*ValidationResult, error) {
+	if s == nil {
+		return nil, fmt.Errorf("schema cannot be nil")
+	}
+	if s.Compiled == nil {
+		if err := s.Compile(); err != nil {
+			return nil, fmt.Errorf("failed to compile schema: %w", err)
+		}
+	}
+	return v.finalizeResult(v.validateCompiled(context.Background(), value, s, v.rootPath()))
+}
+
+// ValidateAgainstDef 仅使用schema中 $defs（或 definitions）下的指定定义验证值，
+// defName 可以是定义名称，也可以是 "#/$defs/Name" 形式的引用
+func (v *Validator) ValidateAgainstDef(data interface{}, s *schema.Schema, defName string) (*ValidationResult, error) {
+	if s == nil {
+		return nil, fmt.Errorf("schema cannot be nil")
+	}
+	if s.Compiled == nil {
+		if err := s.Compile(); err != nil {
+			return nil, fmt.Errorf("failed to compile schema: %w", err)
+		}
+	}
+	def, err := s.Compiled.Definition(defName)
+	if err != nil {
+		return nil, err
+	}
+	return v.finalizeResult(v.validateCompiled(context.Background(), data, &schema.Schema{Compiled: def, Mode: s.Mode}, v.rootPath()))
+}
+
+// validateValue 编译（或从缓存获取）schema并从 path 开始验证值
+func (v *Validator) validateValue(ctx context.Context, value interface{}, schemaJSON string, path string) (*ValidationResult, error) {
+	s, err := v.compiledSchema(schemaJSON)
+	if err != nil {
+		return nil, err
+	}
+	return v.finalizeResult(v.validateCompiled(ctx, value, s, path))
+}
+
+// compiledSchema 解析并编译schema，启用缓存时优先使用缓存的编译结果
+func (v *Validator) compiledSchema(schemaJSON string) (*schema.Schema, error) {
 	// 检查缓存
 	if v.opts.EnableCaching {
 		if cached, ok := v.cache.Load(schemaJSON); ok {
 			if s, ok := cached.(*schema.Schema); ok && s.Compiled != nil {
-				return v.validateCompiledSchema(data, s, "$")
+				return s, nil
 			}
 		}
 	}
 
-	// 解析和编译 schema
-	s, err := schema.Parse(schemaJSON)
+	// 解析和编译 schema
+	s, err := schema.Parse(schemaJSON)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema JSON: %w", err)
+	}
+	v.applyCompileOptions(s)
+	if err := s.Compile(); err != nil {
+		return nil, fmt.Errorf("failed to compile schema: %w", err)
+	}
+	if v.opts.EnableCaching {
+		v.cache.Store(schemaJSON, s)
+	}
+	return s, nil
+}
+
+// applyCompileOptions 将影响编译的选项写入schema：验证模式、模式字符串长度上限，
+// 以及启用未知格式断言时用于检查 format 名称的格式注册表
+func (v *Validator) applyCompileOptions(s *schema.Schema) {
+	s.SetMode(v.opts.ValidationMode)
+	s.SetMaxPatternLength(v.opts.MaxPatternLength)
+	if v.opts.UnknownFormatAssertion {
+		s.SetFormatChecker(v.formats.Known)
+	}
+}
+
+// validateCompiled 使用编译后的 schema 从根开始验证，验证器、模式和选项只在此处写入上下文一次
+func (v *Validator) validateCompiled(ctx context.Context, value interface{}, s *schema.Schema, path string) (*ValidationResult, error) {
+	ctx = context.WithValue(ctx, "validator", v)
+	ctx = context.WithValue(ctx, "validationMode", int(s.Mode))
+	ctx = v.withOptionValues(ctx)
+	// refProperty 等文档级规则需要访问整个文档
+	ctx = context.WithValue(ctx, "rootDocument", value)
+	var coverage map[string]bool
+	if v.opts.Coverage {
+		coverage = make(map[string]bool)
+		ctx = context.WithValue(ctx, "coverage", coverage)
+	}
+	result, err := v.validateCompiledSchema(ctx, value, s.Compiled, s.Mode, path)
+	if result != nil {
+		result.Coverage = coverage
+		result.rootPath = path
+		v.limitErrors(result)
+	}
+	return result, err
+}
+
+// limitErrors 按 ErrorLimit 截断错误：子schema各自在达到上限时停止，合并后可能超出上限
+func (v *Validator) limitErrors(result *ValidationResult) {
+	if v.opts.ErrorLimit > 0 && len(result.Errors) > v.opts.ErrorLimit {
+		result.Errors = result.Errors[:v.opts.ErrorLimit]
+	}
+}
+
+// stopValidation 判断是否需要结束验证：启用 StopOnFirstError（或上下文要求快速失败）时出现错误即结束，
+// 设置 ErrorLimit 时错误数量达到上限即结束
+func (v *Validator) stopValidation(ctx context.Context, result *ValidationResult) bool {
+	if result.Valid {
+		return false
+	}
+	if failFast, _ := ctx.Value("stopOnFirstError").(bool); failFast || v.opts.StopOnFirstError {
+		return true
+	}
+	return v.opts.ErrorLimit > 0 && len(result.Errors) >= v.opts.ErrorLimit
+}
+
+// recordCoverage 在启用 WithCoverage 时记录执行过的关键字位置
+func recordCoverage(ctx context.Context, keywordLocation string) {
+	if coverage, ok := ctx.Value("coverage").(map[string]bool); ok {
+		coverage[keywordLocation] = true
+	}
+}
+
+// validateCompiledSchema 使用编译后的 schema 验证，递归验证子schema时直接传递 CompiledSchema 和模式，
+// 避免为每个子schema分配新的 schema.Schema；
+// 遇到非验证错误时同时返回此前已收集的部分结果，便于调用方排查
+func (v *Validator) validateCompiledSchema(ctx context.Context, value interface{}, compiled *schema.CompiledSchema, mode schema.ValidationMode, path string) (*ValidationResult, error) {
+	result := &ValidationResult{Valid: true, Errors: []errors.ValidationError{}}
+	// 上下文已取消或超时时尽早结束，每个子schema都会检查
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+	// contentMediaType 需要按同级 contentEncoding 解码，每层 schema 重新设置以免继承上层编码
+	ctx = context.WithValue(ctx, "contentEncoding", compiled.Keywords["contentEncoding"])
+	// type 需要读取同级 nullable，同样每层重新设置
+	ctx = context.WithValue(ctx, "nullable", compiled.Keywords["nullable"])
+	// pattern 规则优先使用编译阶段保存的正则表达式
+	ctx = context.WithValue(ctx, "regexps", compiled.Regexps)
+
+	// 布尔schema：true 接受任意值，false 拒绝任意值
+	if compiled.Boolean != nil {
+		if !*compiled.Boolean {
+			result.Valid = false
+			result.Errors = append(result.Errors, falseSchemaError(value, path))
+			setSchemaPath(result.Errors, schemaPathFrom(ctx))
+		}
+		return result, nil
+	}
+	v.collectAnnotations(result, compiled.Keywords, path)
+
+	// 同级 nullable 为 true 时 null 是合法值，其余关键字只约束非 null 值
+	if nullable, _ := compiled.Keywords["nullable"].(bool); nullable && value == nil {
+		return result, nil
+	}
+
+	// 按编译时由 schema.OrderKeywords 确定的顺序验证各关键字（type、required、properties 最先）
+	schemaPath := schemaPathFrom(ctx)
+	order := compiled.Order
+	if len(order) != len(compiled.Keywords) {
+		// 手动构造或修改过的 CompiledSchema 没有有效的顺序
+		order = schema.OrderKeywords(compiled.Keywords)
+	}
+	for _, keyword := range order {
+		schemaValue := compiled.Keywords[keyword]
+		if isAnnotationKey(keyword) || isDefinitionsKey(keyword) {
+			continue
+		}
+		recordCoverage(ctx, schemaPath+"/"+escapeJSONPointer(keyword))
+		start := len(result.Errors)
+		stop, err := v.validateCompiledKeyword(ctx, keyword, schemaValue, value, compiled, mode, path, result)
+		setSchemaPath(result.Errors[start:], schemaPath+"/"+escapeJSONPointer(keyword))
+		if err != nil {
+			return result, err
+		}
+		if stop || v.stopValidation(ctx, result) {
+			return result, nil
+		}
+		// 值不在 enum 中时其余约束已无意义，跳过以减少冗余错误
+		if keyword == "enum" && len(result.Errors) > start {
+			return result, nil
+		}
+	}
+
+	return result, nil
+}
+
+// validateCompiledKeyword 验证编译后schema中的单个关键字，错误追加到 result；
+// 返回 true 表示因 StopOnFirstError 或 ErrorLimit 需要立即结束验证
+func (v *Validator) validateCompiledKeyword(ctx context.Context, keyword string, schemaValue interface{}, value interface{}, compiled *schema.CompiledSchema, mode schema.ValidationMode, path string, result *ValidationResult) (bool, error) {
+
+	// 处理类型关键字
+	if keyword == "type" {
+		validator := v.GetValidator("type")
+		if validator == nil {
+			return false, missingValidatorError("type", path)
+		}
+		isValid, err := validator(ctx, value, schemaValue, path)
+		if err != nil {
+			validErr, ok := err.(*errors.ValidationError)
+			if ok {
+				result.Valid = false
+				result.Errors = append(result.Errors, *validErr)
+			} else {
+				result.Valid = false
+				result.Errors = append(result.Errors, errors.ValidationError{
+					Path:    path,
+					Message: fmt.Sprintf("validation error: %v", err),
+					Tag:     keyword,
+					Value:   value,
+				})
+			}
+		} else if !isValid {
+			result.Valid = false
+		}
+		if v.stopValidation(ctx, result) {
+			return true, nil
+		}
+		return false, nil
+	}
+
+	// 处理 required 关键字，报告每个缺失的属性
+	if keyword == "required" {
+		required, _ := schemaValue.([]string)
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			result.Valid = false
+			result.Errors = append(result.Errors, errors.ValidationError{
+				Path:    path,
+				Message: "value must be an object for required validation",
+				Tag:     "required",
+			})
+			return v.stopValidation(ctx, result), nil
+		}
+		for _, req := range required {
+			if _, exists := obj[req]; !exists {
+				result.Valid = false
+				result.Errors = append(result.Errors, errors.ValidationError{
+					Path:    path + "." + req,
+					Message: fmt.Sprintf("required property '%s' is missing", req),
+					Tag:     "required",
+				})
+				if v.stopValidation(ctx, result) {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	}
+
+	// 处理属性关键字
+	if keyword == "properties" {
+		props, ok := schemaValue.(map[string]*schema.CompiledSchema)
+		if !ok {
+			result.Valid = false
+			result.Errors = append(result.Errors, errors.ValidationError{
+				Path:    path,
+				Message: fmt.Sprintf("properties must be a schema map, got %T", schemaValue),
+				Tag:     "properties",
+			})
+			if v.stopValidation(ctx, result) {
+				return true, nil
+			}
+			return false, nil
+		}
+		if obj, ok := value.(map[string]interface{}); ok {
+			for _, propName := range sortedSchemaNames(props) {
+				propSchema := props[propName]
+				propPath := path + "." + propName
+				if propValue, exists := obj[propName]; exists {
+					propResult, err := v.validateCompiledSchema(withSchemaPath(ctx, "properties", propName), propValue, propSchema, mode, propPath)
+					if err != nil {
+						mergePartialResult(result, propResult)
+						return false, err
+					}
+					result.Warnings = append(result.Warnings, propResult.Warnings...)
+					result.Annotations = append(result.Annotations, propResult.Annotations...)
+					if !propResult.Valid {
+						result.Valid = false
+						result.Errors = append(result.Errors, propResult.Errors...)
+						if v.stopValidation(ctx, result) {
+							return true, nil
+						}
+					}
+				}
+			}
+		} else if v.propertiesRequireObject(compiled.Keywords["type"]) {
+			result.Valid = false
+			result.Errors = append(result.Errors, errors.ValidationError{
+				Path:    path,
+				Message: "value must be an object",
+				Tag:     "properties",
+			})
+			if v.stopValidation(ctx, result) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	// 处理数组元素
+	if keyword == "items" || keyword == "prefixItems" || keyword == "additionalItems" {
+		if arr, ok := value.([]interface{}); ok {
+			itemsResult, err := v.validateArrayItems(ctx, keyword, arr, compiled, mode, path)
+			if err != nil {
+				mergePartialResult(result, itemsResult)
+				return false, err
+			}
+			result.Warnings = append(result.Warnings, itemsResult.Warnings...)
+			result.Annotations = append(result.Annotations, itemsResult.Annotations...)
+			if !itemsResult.Valid {
+				result.Valid = false
+				result.Errors = append(result.Errors, itemsResult.Errors...)
+				if v.stopValidation(ctx, result) {
+					return true, nil
+				}
+			}
+		} else if compiled.Keywords["type"] == "array" {
+			result.Valid = false
+			result.Errors = append(result.Errors, errors.ValidationError{
+				Path:    path,
+				Message: "value must be an array",
+				Tag:     keyword,
+			})
+			if v.stopValidation(ctx, result) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	// 处理模式属性
+	if keyword == "patternProperties" {
+		if obj, ok := value.(map[string]interface{}); ok {
+			patternResult, err := v.validatePatternProperties(ctx, obj, compiled, mode, path)
+			if err != nil {
+				mergePartialResult(result, patternResult)
+				return false, err
+			}
+			result.Warnings = append(result.Warnings, patternResult.Warnings...)
+			result.Annotations = append(result.Annotations, patternResult.Annotations...)
+			if !patternResult.Valid {
+				result.Valid = false
+				result.Errors = append(result.Errors, patternResult.Errors...)
+				if v.stopValidation(ctx, result) {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	}
+
+	// 处理依赖
+	if keyword == "dependencies" {
+		if obj, ok := value.(map[string]interface{}); ok {
+			depResult, err := v.validateDependencies(ctx, obj, compiled, mode, path)
+			if err != nil {
+				mergePartialResult(result, depResult)
+				return false, err
+			}
+			result.Warnings = append(result.Warnings, depResult.Warnings...)
+			result.Annotations = append(result.Annotations, depResult.Annotations...)
+			if !depResult.Valid {
+				result.Valid = false
+				result.Errors = append(result.Errors, depResult.Errors...)
+				if v.stopValidation(ctx, result) {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	}
+
+	// 处理 additionalProperties：布尔 false 拒绝额外属性，子schema 形式验证每个额外属性
+	if keyword == "additionalProperties" {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return false, nil
+		}
+		switch additional := schemaValue.(type) {
+		case bool:
+			if additional || v.opts.AllowUnknownFields {
+				return false, nil
+			}
+			for _, key := range additionalPropertyNames(obj, compiled) {
+				result.Valid = false
+				result.Errors = append(result.Errors, errors.ValidationError{
+					Path:    path + "." + key,
+					Message: "unknown field",
+					Tag:     "additionalProperties",
+					Value:   obj[key],
+				})
+				if v.stopValidation(ctx, result) {
+					return true, nil
+				}
+			}
+		case *schema.CompiledSchema:
+			for _, key := range additionalPropertyNames(obj, compiled) {
+				propResult, err := v.validateCompiledSchema(withSchemaPath(ctx, "additionalProperties"), obj[key], additional, mode, path+"."+key)
+				if err != nil {
+					mergePartialResult(result, propResult)
+					return false, err
+				}
+				result.Warnings = append(result.Warnings, propResult.Warnings...)
+				result.Annotations = append(result.Annotations, propResult.Annotations...)
+				if !propResult.Valid {
+					result.Valid = false
+					result.Errors = append(result.Errors, propResult.Errors...)
+					if v.stopValidation(ctx, result) {
+						return true, nil
+					}
+				}
+			}
+		}
+		return false, nil
+	}
+
+	// 处理其他验证器
+	validator, exists := v.validators[keyword]
+	if !exists {
+		if isMetadataKey(keyword) {
+			return false, nil
+		}
+		if mode == schema.ModeStrict {
+			result.Valid = false
+			result.Errors = append(result.Errors, errors.ValidationError{
+				Path:    path,
+				Message: fmt.Sprintf("unknown validation keyword: %s", keyword),
+				Tag:     keyword,
+			})
+		} else {
+			result.Warnings = append(result.Warnings, unknownKeywordWarning(keyword, path))
+		}
+		return false, nil
+	}
+
+	isValid, err := validator(ctx, value, schemaValue, path)
 	if err != nil {
-		return nil, fmt.Errorf("invalid schema JSON: %w", err)
-	}
-	if err := s.Compile(); err != nil {
-		return nil, fmt.Errorf("failed to compile schema: %w", err)
-	}
-	if v.opts.EnableCaching {
-		v.cache.Store(schemaJSON, s)
+		validErr, ok := err.(*errors.ValidationError)
+		if ok {
+			result.Valid = false
+			result.Errors = append(result.Errors, *validErr)
+		} else if errs, ok := err.(errors.ValidationErrors); ok {
+			// 规则可以一次报告多个错误（如 requiredIfMatch 的每个缺失属性）
+			result.Valid = false
+			result.Errors = append(result.Errors, errs...)
+		} else {
+			result.Valid = false
+			result.Errors = append(result.Errors, errors.ValidationError{
+				Path:    path,
+				Message: fmt.Sprintf("validation error: %v", err),
+				Tag:     keyword,
+				Value:   value,
+			})
+		}
+	} else if !isValid {
+		result.Valid = false
+		result.Errors = append(result.Errors, errors.ValidationError{
+			Path:    path,
+			Message: fmt.Sprintf("validation failed for keyword %s", keyword),
+			Tag:     keyword,
+			Value:   value,
+		})
 	}
-
-	return v.validateCompiledSchema(data, s, "$")
+	return false, nil
 }
 
-// validateCompiledSchema 使用编译后的 schema 验证
-// validator.go
-func (v *Validator) validateCompiledSchema(value interface{}, s *schema.Schema, path string) (*ValidationResult, error) {
+// validateArrayItems 验证编译后的 items/prefixItems/additionalItems 关键字：
+// prefixItems 和元组形式的 items 按位置验证；对象形式的 items 验证 prefixItems 之后的元素；
+// items 为 false 时不允许出现 prefixItems 之外的元素；additionalItems 仅约束元组形式 items 之外的元素
+func (v *Validator) validateArrayItems(ctx context.Context, keyword string, arr []interface{}, compiled *schema.CompiledSchema, mode schema.ValidationMode, path string) (*ValidationResult, error) {
 	result := &ValidationResult{Valid: true, Errors: []errors.ValidationError{}}
-	ctx := context.WithValue(context.Background(), "validator", v)
-	ctx = context.WithValue(ctx, "validationMode", int(s.Mode))
+	prefix, _ := compiled.Keywords["prefixItems"].([]*schema.CompiledSchema)
 
-	// 验证顶层 required 关键字
-	if required, ok := s.Compiled.Keywords["required"].([]string); ok {
-		if obj, ok := value.(map[string]interface{}); ok {
-			for _, req := range required {
-				if _, exists := obj[req]; !exists {
-					result.Valid = false
-					result.Errors = append(result.Errors, errors.ValidationError{
-						Path:    path + "." + req,
-						Message: fmt.Sprintf("required property '%s' is missing", req),
-						Tag:     "required",
-					})
-					if v.opts.StopOnFirstError {
-						return result, nil
-					}
+	validateItem := func(i int, itemSchema *schema.CompiledSchema, schemaTokens ...string) (bool, error) {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		itemResult, err := v.validateCompiledSchema(withSchemaPath(ctx, schemaTokens...), arr[i], itemSchema, mode, itemPath)
+		if err != nil {
+			mergePartialResult(result, itemResult)
+			return false, err
+		}
+		result.Warnings = append(result.Warnings, itemResult.Warnings...)
+		result.Annotations = append(result.Annotations, itemResult.Annotations...)
+		if !itemResult.Valid {
+			result.Valid = false
+			result.Errors = append(result.Errors, itemResult.Errors...)
+			return !v.stopValidation(ctx, result), nil
+		}
+		return true, nil
+	}
+
+	if keyword == "additionalItems" {
+		tuple, ok := compiled.Keywords["items"].([]*schema.CompiledSchema)
+		if !ok || len(arr) <= len(tuple) {
+			return result, nil
+		}
+		switch additional := compiled.Keywords[keyword].(type) {
+		case bool:
+			if !additional {
+				result.Valid = false
+				result.Errors = append(result.Errors, *rules2.AdditionalItemsError(arr, len(tuple), path))
+			}
+		case *schema.CompiledSchema:
+			for i := len(tuple); i < len(arr); i++ {
+				if cont, err := validateItem(i, additional, keyword); err != nil || !cont {
+					return result, err
 				}
 			}
-		} else {
+		}
+		return result, nil
+	}
+
+	switch itemsSchema := compiled.Keywords[keyword].(type) {
+	case []*schema.CompiledSchema:
+		for i := 0; i < len(itemsSchema) && i < len(arr); i++ {
+			if cont, err := validateItem(i, itemsSchema[i], keyword, strconv.Itoa(i)); err != nil || !cont {
+				return result, err
+			}
+		}
+	case *schema.CompiledSchema:
+		for i := len(prefix); i < len(arr); i++ {
+			if cont, err := validateItem(i, itemsSchema, keyword); err != nil || !cont {
+				return result, err
+			}
+		}
+	case bool:
+		if !itemsSchema && len(arr) > len(prefix) {
 			result.Valid = false
 			result.Errors = append(result.Errors, errors.ValidationError{
-				Path:    path,
-				Message: "value must be an object for required validation",
-				Tag:     "required",
+				Path:        path,
+				Message:     "array has more items than allowed",
+				Value:       arr,
+				Tag:         "items",
+				Param:       fmt.Sprintf("%d", len(prefix)),
+				SchemaValue: itemsSchema,
 			})
-			if v.opts.StopOnFirstError {
-				return result, nil
-			}
 		}
+	default:
+		result.Valid = false
+		result.Errors = append(result.Errors, errors.ValidationError{
+			Path:    path,
+			Message: fmt.Sprintf("%s must be a schema, got %T", keyword, itemsSchema),
+			Tag:     keyword,
+		})
 	}
+	return result, nil
+}
 
-	// 处理其他关键字
-	for keyword, schemaValue := range s.Compiled.Keywords {
-		if keyword == "title" || keyword == "description" || keyword == "default" || keyword == "examples" || keyword == "required" {
-			continue
-		}
+// validatePatternProperties 验证编译后的 patternProperties：属性名匹配的每个模式的子schema都需满足
+func (v *Validator) validatePatternProperties(ctx context.Context, obj map[string]interface{}, compiled *schema.CompiledSchema, mode schema.ValidationMode, path string) (*ValidationResult, error) {
+	result := &ValidationResult{Valid: true, Errors: []errors.ValidationError{}}
+	patterns, ok := compiled.Keywords["patternProperties"].(map[string]*schema.CompiledSchema)
+	if !ok {
+		return result, nil
+	}
 
-		// 处理类型关键字
-		if keyword == "type" {
-			validator, exists := v.validators["type"]
-			if exists {
-				isValid, err := validator(ctx, value, schemaValue, path)
-				if err != nil {
-					validErr, ok := err.(*errors.ValidationError)
-					if ok {
-						result.Valid = false
-						result.Errors = append(result.Errors, *validErr)
-					} else {
-						result.Valid = false
-						result.Errors = append(result.Errors, errors.ValidationError{
-							Path:    path,
-							Message: fmt.Sprintf("validation error: %v", err),
-							Tag:     keyword,
-							Value:   value,
-						})
-					}
-				} else if !isValid {
-					result.Valid = false
-				}
-				if !result.Valid && v.opts.StopOnFirstError {
-					return result, nil
-				}
-			}
-			continue
-		}
+	patternNames := make([]string, 0, len(patterns))
+	for pattern := range patterns {
+		patternNames = append(patternNames, pattern)
+	}
+	sort.Strings(patternNames)
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
 
-		// 处理属性关键字
-		if keyword == "properties" {
-			props, ok := schemaValue.(map[string]*schema.CompiledSchema)
-			if !ok {
-				result.Valid = false
-				result.Errors = append(result.Errors, errors.ValidationError{
-					Path:    path,
-					Message: fmt.Sprintf("properties must be a schema map, got %T", schemaValue),
-					Tag:     "properties",
-				})
-				if v.opts.StopOnFirstError {
-					return result, nil
-				}
+	for _, pattern := range patternNames {
+		re, err := compiledRegexp(compiled, pattern)
+		if err != nil {
+			return result, fmt.Errorf("invalid pattern in patternProperties: %s - %w", pattern, err)
+		}
+		for _, key := range keys {
+			if !re.MatchString(key) {
 				continue
 			}
-			if obj, ok := value.(map[string]interface{}); ok {
-				for propName, propSchema := range props {
-					propPath := path + "." + propName
-					if propValue, exists := obj[propName]; exists {
-						propResult, err := v.validateCompiledSchema(propValue, &schema.Schema{Compiled: propSchema, Mode: s.Mode}, propPath)
-						if err != nil {
-							return nil, err
-						}
-						if !propResult.Valid {
-							result.Valid = false
-							result.Errors = append(result.Errors, propResult.Errors...)
-							if v.opts.StopOnFirstError {
-								return result, nil
-							}
-						}
-					}
-				}
-			} else if s.Compiled.Keywords["type"] == "object" {
+			propResult, err := v.validateCompiledSchema(withSchemaPath(ctx, "patternProperties", pattern), obj[key], patterns[pattern], mode, path+"."+key)
+			if err != nil {
+				mergePartialResult(result, propResult)
+				return result, err
+			}
+			result.Warnings = append(result.Warnings, propResult.Warnings...)
+			result.Annotations = append(result.Annotations, propResult.Annotations...)
+			if !propResult.Valid {
 				result.Valid = false
-				result.Errors = append(result.Errors, errors.ValidationError{
-					Path:    path,
-					Message: "value must be an object",
-					Tag:     "properties",
-				})
-				if v.opts.StopOnFirstError {
+				result.Errors = append(result.Errors, propResult.Errors...)
+				if v.stopValidation(ctx, result) {
 					return result, nil
 				}
 			}
-			continue
 		}
+	}
+	return result, nil
+}
 
-		// 处理数组元素
-		if keyword == "items" {
-			itemsSchema, ok := schemaValue.(*schema.CompiledSchema)
-			if !ok {
+// validateDependencies 验证编译后的 dependencies：属性存在时，数组形式要求依赖的属性同时存在，
+// schema 形式要求整个对象满足该子schema
+func (v *Validator) validateDependencies(ctx context.Context, obj map[string]interface{}, compiled *schema.CompiledSchema, mode schema.ValidationMode, path string) (*ValidationResult, error) {
+	result := &ValidationResult{Valid: true, Errors: []errors.ValidationError{}}
+	deps, ok := compiled.Keywords["dependencies"].(map[string]interface{})
+	if !ok {
+		return result, nil
+	}
+
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if _, exists := obj[name]; !exists {
+			continue
+		}
+		switch dep := deps[name].(type) {
+		case []string:
+			for _, required := range dep {
+				if _, exists := obj[required]; exists {
+					continue
+				}
 				result.Valid = false
 				result.Errors = append(result.Errors, errors.ValidationError{
 					Path:    path,
-					Message: fmt.Sprintf("items must be a schema, got %T", schemaValue),
-					Tag:     "items",
+					Message: fmt.Sprintf("property '%s' depends on '%s', but it is missing", name, required),
+					Value:   obj,
+					Tag:     "dependencies",
+					Param:   required,
 				})
-				if v.opts.StopOnFirstError {
+				if v.stopValidation(ctx, result) {
 					return result, nil
 				}
-				continue
 			}
-			if arr, ok := value.([]interface{}); ok {
-				for i, item := range arr {
-					itemPath := fmt.Sprintf("%s[%d]", path, i)
-					itemResult, err := v.validateCompiledSchema(item, &schema.Schema{Compiled: itemsSchema, Mode: s.Mode}, itemPath)
-					if err != nil {
-						return nil, err
-					}
-					if !itemResult.Valid {
-						result.Valid = false
-						result.Errors = append(result.Errors, itemResult.Errors...)
-						if v.opts.StopOnFirstError {
-							return result, nil
-						}
-					}
-				}
-			} else if s.Compiled.Keywords["type"] == "array" {
+		case *schema.CompiledSchema:
+			depResult, err := v.validateCompiledSchema(withSchemaPath(ctx, "dependencies", name), obj, dep, mode, path)
+			if err != nil {
+				mergePartialResult(result, depResult)
+				return result, err
+			}
+			result.Warnings = append(result.Warnings, depResult.Warnings...)
+			result.Annotations = append(result.Annotations, depResult.Annotations...)
+			if !depResult.Valid {
 				result.Valid = false
-				result.Errors = append(result.Errors, errors.ValidationError{
-					Path:    path,
-					Message: "value must be an array",
-					Tag:     "items",
-				})
-				if v.opts.StopOnFirstError {
+				result.Errors = append(result.Errors, depResult.Errors...)
+				if v.stopValidation(ctx, result) {
 					return result, nil
 				}
 			}
-			continue
 		}
+	}
+	return result, nil
+}
 
-		// 处理 additionalProperties
-		if keyword == "additionalProperties" {
-			if additionalProps, ok := schemaValue.(bool); ok && !additionalProps && !v.opts.AllowUnknownFields {
-				if obj, ok := value.(map[string]interface{}); ok {
-					props, _ := s.Compiled.Keywords["properties"].(map[string]*schema.CompiledSchema)
-					for key := range obj {
-						if _, exists := props[key]; !exists {
-							result.Valid = false
-							result.Errors = append(result.Errors, errors.ValidationError{
-								Path:    path + "." + key,
-								Message: "unknown field",
-								Tag:     "additionalProperties",
-								Value:   obj[key],
-							})
-							if v.opts.StopOnFirstError {
-								return result, nil
-							}
-						}
-					}
-				}
-			}
-			continue
-		}
+// mergePartialResult 在子schema返回非验证错误时合并其已收集的部分结果
+func mergePartialResult(result *ValidationResult, partial *ValidationResult) {
+	if partial == nil {
+		return
+	}
+	result.Warnings = append(result.Warnings, partial.Warnings...)
+	result.Annotations = append(result.Annotations, partial.Annotations...)
+	if len(partial.Errors) > 0 {
+		result.Valid = false
+		result.Errors = append(result.Errors, partial.Errors...)
+	}
+}
 
-		// 处理其他验证器
-		validator, exists := v.validators[keyword]
-		if !exists {
-			if s.Mode == schema.ModeStrict && !isMetadataKey(keyword) {
-				result.Valid = false
-				result.Errors = append(result.Errors, errors.ValidationError{
-					Path:    path,
-					Message: fmt.Sprintf("unknown validation keyword: %s", keyword),
-					Tag:     keyword,
-				})
-			}
-			continue
+// propertiesRequireObject 判断 properties 是否要求值为对象：type 显式为 "object"，
+// 或启用 ImplicitObjectType 且未声明 type
+func (v *Validator) propertiesRequireObject(typeValue interface{}) bool {
+	return typeValue == "object" || (typeValue == nil && v.opts.ImplicitObjectType)
+}
+
+// additionalPropertyNames 按名称排序返回既不在 properties 中、也不匹配任何 patternProperties 模式的属性
+func additionalPropertyNames(obj map[string]interface{}, compiled *schema.CompiledSchema) []string {
+	props, _ := compiled.Keywords["properties"].(map[string]*schema.CompiledSchema)
+	patterns, _ := compiled.Keywords["patternProperties"].(map[string]*schema.CompiledSchema)
+	var names []string
+	for key := range obj {
+		if _, exists := props[key]; !exists && !matchesAnyPattern(key, compiled, patterns) {
+			names = append(names, key)
 		}
+	}
+	sort.Strings(names)
+	return names
+}
 
-		isValid, err := validator(ctx, value, schemaValue, path)
-		if err != nil {
-			validErr, ok := err.(*errors.ValidationError)
-			if ok {
-				result.Valid = false
-				result.Errors = append(result.Errors, *validErr)
-			} else {
-				result.Valid = false
-				result.Errors = append(result.Errors, errors.ValidationError{
-					Path:    path,
-					Message: fmt.Sprintf("validation error: %v", err),
-					Tag:     keyword,
-					Value:   value,
-				})
-			}
-		} else if !isValid {
-			result.Valid = false
-			result.Errors = append(result.Errors, errors.ValidationError{
-				Path:    path,
-				Message: fmt.Sprintf("validation failed for keyword %s", keyword),
-				Tag:     keyword,
-				Value:   value,
-			})
+// matchesAnyPattern 检查属性名是否匹配任一 patternProperties 模式
+func matchesAnyPattern(key string, compiled *schema.CompiledSchema, patterns map[string]*schema.CompiledSchema) bool {
+	for pattern := range patterns {
+		if re, err := compiledRegexp(compiled, pattern); err == nil && re.MatchString(key) {
+			return true
 		}
+	}
+	return false
+}
 
-		if !result.Valid && v.opts.StopOnFirstError {
-			return result, nil
+// compiledRegexp 返回编译阶段保存的正则表达式，手动构造的 CompiledSchema 中没有时再编译
+func compiledRegexp(compiled *schema.CompiledSchema, pattern string) (*regexp.Regexp, error) {
+	if re, ok := compiled.Regexps[pattern]; ok {
+		return re, nil
+	}
+	return regexp.Compile(pattern)
+}
+
+// withOptionValues 将规则需要读取的选项和实例级格式注册表写入上下文
+func (v *Validator) withOptionValues(ctx context.Context) context.Context {
+	ctx = context.WithValue(ctx, "formats", v.formats)
+	ctx = context.WithValue(ctx, "byteLength", v.opts.ByteLength)
+	ctx = context.WithValue(ctx, "formatAssertion", v.opts.FormatAssertion)
+	ctx = context.WithValue(ctx, "unknownFormatAssertion", v.opts.UnknownFormatAssertion)
+	ctx = context.WithValue(ctx, "coerceTypes", v.opts.CoerceTypes)
+	ctx = context.WithValue(ctx, "strictTypes", v.opts.StrictTypes)
+	if v.opts.NumberParser != nil {
+		ctx = context.WithValue(ctx, "numberParser", v.opts.NumberParser)
+	}
+	if v.opts.Clock != nil {
+		ctx = context.WithValue(ctx, "clock", v.opts.Clock)
+	}
+	for key, val := range v.opts.ContextValues {
+		if ctx.Value(key) == nil {
+			ctx = context.WithValue(ctx, key, val)
 		}
 	}
+	return ctx
+}
+
+// falseSchemaError 构造值被 false schema 拒绝时的错误
+func falseSchemaError(value interface{}, path string) errors.ValidationError {
+	return errors.ValidationError{
+		Path:    path,
+		Message: "value is not allowed by false schema",
+		Value:   value,
+		Tag:     "false_schema",
+	}
+}
 
-	return result, nil
+// unknownKeywordWarning 构造非严格模式下遇到未知关键字时的警告
+func unknownKeywordWarning(keyword string, path string) errors.ValidationError {
+	return errors.ValidationError{
+		Path:    path,
+		Message: fmt.Sprintf("unknown validation keyword ignored: %s", keyword),
+		Tag:     keyword,
+	}
+}
+
+// missingValidatorError 构造关键字对应的验证器未注册时的错误
+func missingValidatorError(keyword string, path string) *errors.ValidationError {
+	return &errors.ValidationError{
+		Path:    path,
+		Message: fmt.Sprintf("no validator registered for keyword '%s': register one with RegisterValidator or create the validator with New to include built-in rules", keyword),
+		Tag:     keyword,
+		Param:   keyword,
+	}
+}
+
+// isDefinitionsKey 检查关键字是否为子schema定义块，定义块本身不参与验证
+func isDefinitionsKey(key string) bool {
+	return key == "$defs" || key == "definitions"
 }
 
 // isMetadataKey 检查关键字是否为元数据
 func isMetadataKey(key string) bool {
-	return key == "$id" || key == "title" || key == "description" || key == "$schema" || key == "$comment"
+	return key == "$id" || key == "title" || key == "description" || key == "$schema" || key == "$comment" || isAnnotationKey(key)
 }
 
 // ValidationResult 包含验证结果
 type ValidationResult struct {
 	Valid  bool                     `json:"valid"`
 	Errors []errors.ValidationError `json:"errors,omitempty"`
+	// Warnings 记录不影响验证结果的问题，例如非严格模式下被忽略的未知关键字
+	Warnings []errors.ValidationError `json:"warnings,omitempty"`
+	// Annotations 启用 WithCollectAnnotations 时记录各实例路径上的注解
+	Annotations []Annotation `json:"annotations,omitempty"`
+	// Coverage 启用 WithCoverage 时记录实际执行过的关键字，键为关键字在schema中的位置（如 "/properties/age/minimum"）
+	Coverage map[string]bool `json:"coverage,omitempty"`
+	// rootPath 记录验证开始时的根路径标记，ToOutputFormat 据此生成相对于被验证实例的 JSON Pointer
+	rootPath string
+}
+
+// ByPath 按错误路径分组验证错误，便于将错误映射到表单字段
+func (r *ValidationResult) ByPath() errors.ValidationErrorMap {
+	m := make(errors.ValidationErrorMap)
+	for _, e := range r.Errors {
+		m[e.Path] = append(m[e.Path], e)
+	}
+	return m
 }
 
 // GetValidator 获取已注册的验证器
@@ -573,6 +1408,30 @@ func (v *Validator) GetComparator(name string) comparators.CompareFunc {
 	return v.comparators[name]
 }
 
+// ListValidators 返回已注册验证器名称的有序列表
+func (v *Validator) ListValidators() []string {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+	names := make([]string, 0, len(v.validators))
+	for name := range v.validators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ListComparators 返回已注册比较函数名称的有序列表
+func (v *Validator) ListComparators() []string {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+	names := make([]string, 0, len(v.comparators))
+	for name := range v.comparators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // parseTag 解析验证标签
 func (v *Validator) parseTag(tag string) map[string]interface{} {
 	result := make(map[string]interface{})
@@ -592,7 +1451,7 @@ func (v *Validator) parseTag(tag string) map[string]interface{} {
 			key := strings.TrimSpace(kv[0])
 			value := strings.TrimSpace(kv[1])
 			switch key {
-			case "min", "max", "minLength", "maxLength", "minimum", "maximum":
+			case "min", "max", "minLength", "maxLength", "minBytes", "maxBytes", "minItems", "maxItems", "minProperties", "maxProperties", "minimum", "maximum", "len", "gt", "gte", "lt", "lte":
 				if num, err := strconv.Atoi(value); err == nil {
 					result[key] = num
 				} else if num, err := strconv.ParseFloat(value, 64); err == nil {
@@ -604,6 +1463,17 @@ func (v *Validator) parseTag(tag string) map[string]interface{} {
 				result[key] = value
 			case "enum":
 				result[key] = strings.Split(value, "|")
+			case "oneof":
+				// 空格分隔，能解析为数字的按数字处理
+				var values []interface{}
+				for _, item := range strings.Fields(value) {
+					if num, err := strconv.ParseFloat(item, 64); err == nil {
+						values = append(values, num)
+					} else {
+						values = append(values, item)
+					}
+				}
+				result[key] = values
 			default:
 				result[key] = value
 			}
@@ -614,6 +1484,17 @@ func (v *Validator) parseTag(tag string) map[string]interface{} {
 	return result
 }
 
+// fieldPathName 返回结构体字段在错误路径中使用的名称
+func (v *Validator) fieldPathName(field reflect.StructField) string {
+	if v.opts.JSONFieldNames {
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
+
 func isZero(v reflect.Value) bool {
 	if !v.IsValid() {
 		return true
@@ -640,7 +1521,7 @@ func isZero(v reflect.Value) bool {
 	}
 }
 
-// CompileSchema 编译Schema以提高重复使用的性能
+// CompileSchema 编译Schema以提高重复使用的性能；启用缓存时返回的是缓存中共享的实例，修改前应先调用 Clone
 func (v *Validator) CompileSchema(schemaJSON string) (*schema.Schema, error) {
 	if v.opts.EnableCaching {
 		if cached, ok := v.cache.Load(schemaJSON); ok {
@@ -658,6 +1539,7 @@ func (v *Validator) CompileSchema(schemaJSON string) (*schema.Schema, error) {
 			Tag:     "schema_parse",
 		}
 	}
+	v.applyCompileOptions(s)
 	if err := s.Compile(); err != nil {
 		return nil, &errors.ValidationError{
 			Path:    "$",
@@ -673,18 +1555,39 @@ func (v *Validator) CompileSchema(schemaJSON string) (*schema.Schema, error) {
 
 // ValidateWithSchema 使用指定的schema验证值
 func (v *Validator) ValidateWithSchema(value interface{}, schemaMap map[string]interface{}, path string) (*ValidationResult, error) {
+	result, err := v.validateWithSchema(value, schemaMap, path)
+	if result != nil {
+		result.rootPath = path
+		v.limitErrors(result)
+	}
+	return v.finalizeResult(result, err)
+}
+
+// validateWithSchema 执行基于schema映射的验证，遇到非验证错误时同时返回此前已收集的部分结果
+func (v *Validator) validateWithSchema(value interface{}, schemaMap map[string]interface{}, path string) (*ValidationResult, error) {
 	result := &ValidationResult{Valid: true, Errors: []errors.ValidationError{}}
 	ctx := context.WithValue(context.Background(), "validator", v)
+	ctx = context.WithValue(ctx, "validationMode", int(v.opts.ValidationMode))
+	ctx = v.withOptionValues(ctx)
+	ctx = context.WithValue(ctx, "contentEncoding", schemaMap["contentEncoding"])
+	ctx = context.WithValue(ctx, "nullable", schemaMap["nullable"])
+	ctx = context.WithValue(ctx, "prefixItems", schemaMap["prefixItems"])
+	ctx = context.WithValue(ctx, "items", schemaMap["items"])
+	// additionalProperties 需要知道同级 properties 和 patternProperties 覆盖了哪些属性
+	ctx = context.WithValue(ctx, "properties", schemaMap["properties"])
+	ctx = context.WithValue(ctx, "patternProperties", schemaMap["patternProperties"])
+	v.collectAnnotations(result, schemaMap, path)
+
+	// 同级 nullable 为 true 时 null 是合法值，其余关键字只约束非 null 值
+	if nullable, _ := schemaMap["nullable"].(bool); nullable && value == nil {
+		return result, nil
+	}
 
 	// 处理类型关键字
 	if typeVal, ok := schemaMap["type"]; ok {
-		validator, exists := v.validators["type"]
-		if !exists {
-			return nil, &errors.ValidationError{
-				Path:    path,
-				Message: "type validator not found",
-				Tag:     "type",
-			}
+		validator := v.GetValidator("type")
+		if validator == nil {
+			return result, missingValidatorError("type", path)
 		}
 		isValid, err := validator(ctx, value, typeVal, path)
 		if err != nil {
@@ -703,7 +1606,7 @@ func (v *Validator) ValidateWithSchema(value interface{}, schemaMap map[string]i
 		} else if !isValid {
 			result.Valid = false
 		}
-		if !result.Valid && v.opts.StopOnFirstError {
+		if v.stopValidation(ctx, result) {
 			return result, nil
 		}
 	}
@@ -712,43 +1615,43 @@ func (v *Validator) ValidateWithSchema(value interface{}, schemaMap map[string]i
 	if requiredVal, ok := schemaMap["required"]; ok {
 		requiredFields, ok := requiredVal.([]interface{})
 		if !ok {
-			return nil, &errors.ValidationError{
+			return result, &errors.ValidationError{
 				Path:    path,
 				Message: "required must be an array",
 				Tag:     "required",
 				Value:   requiredVal,
 			}
 		}
-		obj, ok := value.(map[string]interface{})
-		if !ok {
+		obj, isObject := value.(map[string]interface{})
+		if !isObject {
 			result.Valid = false
 			result.Errors = append(result.Errors, errors.ValidationError{
 				Path:    path,
-				Message: "value must be an object",
+				Message: "value must be an object for required validation",
 				Tag:     "required",
 			})
-			if v.opts.StopOnFirstError {
+			if v.stopValidation(ctx, result) {
 				return result, nil
 			}
 		}
 		for _, field := range requiredFields {
 			fieldStr, ok := field.(string)
 			if !ok {
-				return nil, &errors.ValidationError{
+				return result, &errors.ValidationError{
 					Path:    path,
 					Message: "required field must be a string",
 					Tag:     "required",
 					Value:   field,
 				}
 			}
-			if _, exists := obj[fieldStr]; !exists {
+			if _, exists := obj[fieldStr]; isObject && !exists {
 				result.Valid = false
 				result.Errors = append(result.Errors, errors.ValidationError{
 					Path:    path + "." + fieldStr,
 					Message: fmt.Sprintf("required property '%s' is missing", fieldStr),
 					Tag:     "required",
 				})
-				if v.opts.StopOnFirstError {
+				if v.stopValidation(ctx, result) {
 					return result, nil
 				}
 			}
@@ -758,21 +1661,32 @@ func (v *Validator) ValidateWithSchema(value interface{}, schemaMap map[string]i
 	// 处理对象属性
 	if props, ok := schemaMap["properties"].(map[string]interface{}); ok {
 		obj, ok := value.(map[string]interface{})
-		if !ok && schemaMap["type"] == "object" {
+		if !ok && v.propertiesRequireObject(schemaMap["type"]) {
 			result.Valid = false
 			result.Errors = append(result.Errors, errors.ValidationError{
 				Path:    path,
 				Message: "value must be an object",
 				Tag:     "properties",
 			})
-			if v.opts.StopOnFirstError {
+			if v.stopValidation(ctx, result) {
 				return result, nil
 			}
 		}
-		for propName, propSchema := range props {
+		for _, propName := range sortedPropertyNames(props) {
+			propSchema := props[propName]
+			if allowed, ok := propSchema.(bool); ok {
+				if propVal, exists := obj[propName]; exists && !allowed {
+					result.Valid = false
+					result.Errors = append(result.Errors, falseSchemaError(propVal, path+"."+propName))
+					if v.stopValidation(ctx, result) {
+						return result, nil
+					}
+				}
+				continue
+			}
 			propMap, ok := propSchema.(map[string]interface{})
 			if !ok {
-				return nil, &errors.ValidationError{
+				return result, &errors.ValidationError{
 					Path:    path + "." + propName,
 					Message: fmt.Sprintf("property '%s' schema must be an object", propName),
 					Tag:     "properties",
@@ -781,14 +1695,17 @@ func (v *Validator) ValidateWithSchema(value interface{}, schemaMap map[string]i
 			}
 			propPath := path + "." + propName
 			if propVal, exists := obj[propName]; exists {
-				propResult, err := v.ValidateWithSchema(propVal, propMap, propPath)
+				propResult, err := v.validateWithSchema(propVal, propMap, propPath)
 				if err != nil {
-					return nil, err
+					mergePartialResult(result, propResult)
+					return result, err
 				}
+				result.Warnings = append(result.Warnings, propResult.Warnings...)
+				result.Annotations = append(result.Annotations, propResult.Annotations...)
 				if !propResult.Valid {
 					result.Valid = false
 					result.Errors = append(result.Errors, propResult.Errors...)
-					if v.opts.StopOnFirstError {
+					if v.stopValidation(ctx, result) {
 						return result, nil
 					}
 				}
@@ -796,9 +1713,41 @@ func (v *Validator) ValidateWithSchema(value interface{}, schemaMap map[string]i
 		}
 	}
 
-	// 处理其他关键字
-	for keyword, schemaValue := range schemaMap {
-		if keyword == "type" || keyword == "properties" || keyword == "required" || keyword == "title" || keyword == "description" || keyword == "default" || keyword == "examples" {
+	// 处理条件关键字，if/then/else 需要作为整体评估
+	_, hasIf := schemaMap["if"]
+	if hasIf {
+		conditional := make(map[string]interface{}, 3)
+		for _, key := range []string{"if", "then", "else"} {
+			if val, ok := schemaMap[key]; ok {
+				conditional[key] = val
+			}
+		}
+		isValid, err := rules2.ValidateConditional(ctx, value, conditional, path)
+		if err != nil || !isValid {
+			result.Valid = false
+			if ve, ok := err.(*errors.ValidationError); ok {
+				result.Errors = append(result.Errors, *ve)
+			} else {
+				result.Errors = append(result.Errors, errors.ValidationError{
+					Path:    path,
+					Message: fmt.Sprintf("conditional validation failed: %v", err),
+					Tag:     "if",
+					Value:   value,
+				})
+			}
+			if v.stopValidation(ctx, result) {
+				return result, nil
+			}
+		}
+	}
+
+	// 处理其他关键字，与编译后的schema使用相同的顺序（schema.OrderKeywords）
+	for _, keyword := range schema.OrderKeywords(schemaMap) {
+		schemaValue := schemaMap[keyword]
+		if keyword == "type" || keyword == "properties" || keyword == "required" || isAnnotationKey(keyword) || isDefinitionsKey(keyword) {
+			continue
+		}
+		if hasIf && (keyword == "if" || keyword == "then" || keyword == "else") {
 			continue
 		}
 		validator, exists := v.validators[keyword]
@@ -810,6 +1759,8 @@ func (v *Validator) ValidateWithSchema(value interface{}, schemaMap map[string]i
 					Message: fmt.Sprintf("unknown validation keyword: %s", keyword),
 					Tag:     keyword,
 				})
+			} else if !isMetadataKey(keyword) {
+				result.Warnings = append(result.Warnings, unknownKeywordWarning(keyword, path))
 			}
 			continue
 		}
@@ -818,6 +1769,9 @@ func (v *Validator) ValidateWithSchema(value interface{}, schemaMap map[string]i
 			if ve, ok := err.(*errors.ValidationError); ok {
 				result.Valid = false
 				result.Errors = append(result.Errors, *ve)
+			} else if errs, ok := err.(errors.ValidationErrors); ok {
+				result.Valid = false
+				result.Errors = append(result.Errors, errs...)
 			} else {
 				result.Valid = false
 				result.Errors = append(result.Errors, errors.ValidationError{
@@ -836,7 +1790,11 @@ func (v *Validator) ValidateWithSchema(value interface{}, schemaMap map[string]i
 				Value:   value,
 			})
 		}
-		if !result.Valid && v.opts.StopOnFirstError {
+		if v.stopValidation(ctx, result) {
+			return result, nil
+		}
+		// 与编译后的schema一致：值不在 enum 中时跳过其余约束
+		if keyword == "enum" && (err != nil || !isValid) {
 			return result, nil
 		}
 	}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"sync"
 	"testing"
 
 	"github.com/songzhibin97/jsonschema-validator/comparators"
+	"github.com/songzhibin97/jsonschema-validator/errors"
 	"github.com/songzhibin97/jsonschema-validator/rules"
 	"github.com/stretchr/testify/assert"
 )
@@ -128,6 +130,14 @@ func TestParseTag(t *testing.T) {
 				"enum":     []string{"a", "b", "c"},
 			},
 		},
+		{
+			name: "数组长度",
+			tag:  "minItems=1,maxItems=3",
+			expected: map[string]interface{}{
+				"minItems": 1,
+				"maxItems": 3,
+			},
+		},
 		{
 			name: "无效数字",
 			tag:  "min=abc",
@@ -230,3 +240,52 @@ func TestConcurrentRegistration(t *testing.T) {
 		assert.NotNil(t, fn, "验证器 rule%d 应已注册", i)
 	}
 }
+
+// 测试已注册验证器和比较器的列举
+func TestListValidatorsAndComparators(t *testing.T) {
+	v := New()
+
+	validators := v.ListValidators()
+	assert.True(t, sort.StringsAreSorted(validators), "验证器名称应有序")
+	for _, name := range []string{"type", "required", "minimum", "pattern", "format", "const"} {
+		assert.Contains(t, validators, name)
+	}
+
+	assert.Equal(t, []string{"eq", "ge", "gt", "le", "lt", "ne"}, v.ListComparators())
+
+	// 返回的是副本，修改不影响注册表
+	validators[0] = "changed"
+	assert.NotContains(t, v.ListValidators(), "changed")
+
+	err := v.RegisterComparator("custom", func(a, b interface{}) bool { return true })
+	assert.NoError(t, err)
+	assert.Contains(t, v.ListComparators(), "custom")
+}
+
+// 测试缺少 type 验证器时返回可操作的错误
+func TestMissingTypeValidator(t *testing.T) {
+	v := New()
+	delete(v.validators, "type")
+
+	_, err := v.ValidateWithSchema("John", map[string]interface{}{"type": "string"}, "root")
+	if assert.Error(t, err) {
+		ve, ok := err.(*errors.ValidationError)
+		if assert.True(t, ok, "应返回 *errors.ValidationError") {
+			assert.Equal(t, "type", ve.Tag)
+			assert.Equal(t, "root", ve.Path)
+			assert.Contains(t, ve.Message, "RegisterValidator")
+		}
+	}
+
+	_, err = v.ValidateJSON(`"John"`, `{"type":"string"}`)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no validator registered for keyword 'type'")
+
+	// 重新注册后恢复正常
+	v.RegisterValidatorMust("type", func(ctx context.Context, value interface{}, schema interface{}, path string) (bool, error) {
+		return true, nil
+	})
+	result, err := v.ValidateWithSchema("John", map[string]interface{}{"type": "string"}, "root")
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+}
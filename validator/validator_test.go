@@ -2,10 +2,16 @@ package validator
 
 import (
 	"context"
+	"encoding/json"
+	goerrors "errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/songzhibin97/jsonschema-validator/errors"
 	"github.com/songzhibin97/jsonschema-validator/schema"
@@ -318,3 +324,2158 @@ func TestConcurrentValidation(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestLooseModeUnknownKeywordWarnings(t *testing.T) {
+	v := New(WithValidationMode(schema.ModeLoose))
+	schemaJSON := `{"type":"object","properties":{"name":{"type":"string","maxLenght":3}}}`
+
+	result, err := v.ValidateJSON(`{"name":"John"}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Empty(t, result.Errors)
+	if assert.Len(t, result.Warnings, 1) {
+		assert.Equal(t, "maxLenght", result.Warnings[0].Tag)
+		assert.Equal(t, "$.name", result.Warnings[0].Path)
+		assert.Contains(t, result.Warnings[0].Message, "maxLenght")
+	}
+
+	schemaMap := map[string]interface{}{"type": "string", "minLenght": 2}
+	result, err = v.ValidateWithSchema("a", schemaMap, "root")
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+	if assert.Len(t, result.Warnings, 1) {
+		assert.Equal(t, "minLenght", result.Warnings[0].Tag)
+	}
+
+	// 严格模式下仍然报告为错误
+	_, err = New().ValidateJSON(`{"name":"John"}`, schemaJSON)
+	assert.Error(t, err)
+}
+
+func TestValidateValue(t *testing.T) {
+	v := New(WithCaching(true))
+	objectSchema := `{"type":"object","properties":{"name":{"type":"string"},"age":{"type":"integer","minimum":18}},"required":["name"]}`
+
+	result, err := v.ValidateValue(map[string]interface{}{"name": "John", "age": float64(30)}, objectSchema)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+
+	result, err = v.ValidateValue(map[string]interface{}{"age": float64(10)}, objectSchema)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Len(t, result.Errors, 2)
+
+	// 复用编译缓存
+	_, ok := v.cache.Load(objectSchema)
+	assert.True(t, ok)
+
+	arraySchema := `{"type":"array","items":{"type":"string"},"minItems":2}`
+	result, err = v.ValidateValue([]interface{}{"a", "b"}, arraySchema)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+
+	result, err = v.ValidateValue([]interface{}{"a", float64(1)}, arraySchema)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "$[1]", result.Errors[0].Path)
+	}
+
+	_, err = v.ValidateValue("x", `{`)
+	assert.Error(t, err)
+}
+
+func TestValidateAgainst(t *testing.T) {
+	v := New()
+	s, err := v.CompileSchema(`{"type":"object","properties":{"age":{"type":"integer","minimum":18}},"required":["age"]}`)
+	assert.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		result, err := v.ValidateAgainst(map[string]interface{}{"age": float64(20 + i)}, s)
+		assert.NoError(t, err)
+		assert.True(t, result.Valid)
+	}
+
+	result, err := v.ValidateAgainst(map[string]interface{}{"age": float64(10)}, s)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "$.age", result.Errors[0].Path)
+	}
+
+	// 未编译的 schema 会先编译
+	raw, err := schema.Parse(`{"type":"string"}`)
+	assert.NoError(t, err)
+	result, err = v.ValidateAgainst("ok", raw)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+
+	_, err = v.ValidateAgainst("ok", nil)
+	assert.Error(t, err)
+}
+
+func BenchmarkValidateAgainst(b *testing.B) {
+	v := New()
+	s, err := v.CompileSchema(`{"type":"object","properties":{"name":{"type":"string","minLength":1},"age":{"type":"integer","minimum":18}},"required":["name"]}`)
+	if err != nil {
+		b.Fatal(err)
+	}
+	value := map[string]interface{}{"name": "John", "age": float64(30)}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := v.ValidateAgainst(value, s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkValidateLargeObject(b *testing.B) {
+	v := New()
+	props := make(map[string]interface{}, 200)
+	value := make(map[string]interface{}, 200)
+	for i := 0; i < 200; i++ {
+		name := fmt.Sprintf("field%d", i)
+		props[name] = map[string]interface{}{"type": "string", "minLength": float64(1)}
+		value[name] = "value"
+	}
+	raw, err := json.Marshal(map[string]interface{}{"type": "object", "properties": props})
+	if err != nil {
+		b.Fatal(err)
+	}
+	s, err := v.CompileSchema(string(raw))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := v.ValidateAgainst(value, s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestValidateJSONFile(t *testing.T) {
+	v := New()
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	schemaPath := writeFile("schema.json", `{"type":"object","properties":{"name":{"type":"string"}},"required":["name"]}`)
+	validPath := writeFile("valid.json", `{"name":"John"}`)
+	invalidPath := writeFile("invalid.json", `{"name":1}`)
+	brokenPath := writeFile("broken.json", `{"name":`)
+
+	result, err := v.ValidateJSONFile(validPath, schemaPath)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+
+	result, err = v.ValidateJSONFile(invalidPath, schemaPath)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+
+	_, err = v.ValidateJSONFile(brokenPath, schemaPath)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid JSON data")
+
+	missing := filepath.Join(dir, "missing.json")
+	_, err = v.ValidateJSONFile(missing, schemaPath)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read data file")
+	assert.True(t, goerrors.Is(err, os.ErrNotExist))
+
+	_, err = v.ValidateJSONFile(validPath, missing)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read schema file")
+}
+
+func TestValidateWithSchemaConditional(t *testing.T) {
+	v := New()
+	schemaMap := map[string]interface{}{
+		"type": "object",
+		"if": map[string]interface{}{
+			"properties": map[string]interface{}{"kind": map[string]interface{}{"const": "a"}},
+		},
+		"then": map[string]interface{}{"required": []interface{}{"x"}},
+		"else": map[string]interface{}{"required": []interface{}{"y"}},
+	}
+
+	tests := []struct {
+		name        string
+		value       map[string]interface{}
+		expectValid bool
+		errPath     string
+	}{
+		{"Then satisfied", map[string]interface{}{"kind": "a", "x": 1}, true, ""},
+		{"Then violated", map[string]interface{}{"kind": "a"}, false, "root.then"},
+		{"Else satisfied", map[string]interface{}{"kind": "b", "y": 1}, true, ""},
+		{"Else violated", map[string]interface{}{"kind": "b", "x": 1}, false, "root.else"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := v.ValidateWithSchema(tt.value, schemaMap, "root")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectValid, result.Valid)
+			if tt.errPath != "" && assert.Len(t, result.Errors, 1) {
+				assert.Equal(t, tt.errPath, result.Errors[0].Path)
+				assert.Equal(t, "required", result.Errors[0].Tag)
+			}
+		})
+	}
+}
+
+func TestValidateJSONExclusiveBounds(t *testing.T) {
+	v := New()
+	tests := []struct {
+		name   string
+		schema string
+		data   string
+		valid  bool
+	}{
+		{"Draft-04 boolean rejects boundary", `{"minimum": 5, "exclusiveMinimum": true}`, `5`, false},
+		{"Draft-04 boolean accepts above", `{"minimum": 5, "exclusiveMinimum": true}`, `6`, true},
+		{"Draft-04 boolean false inclusive", `{"maximum": 5, "exclusiveMaximum": false}`, `5`, true},
+		{"Draft-06 numeric rejects boundary", `{"exclusiveMinimum": 5}`, `5`, false},
+		{"Draft-06 numeric maximum", `{"exclusiveMaximum": 10}`, `9.5`, true},
+		{"Open interval inside", `{"type": "number", "exclusiveMinimum": 0, "exclusiveMaximum": 10}`, `5`, true},
+		{"Open interval near lower bound", `{"type": "number", "exclusiveMinimum": 0, "exclusiveMaximum": 10}`, `0.001`, true},
+		{"Open interval lower boundary", `{"type": "number", "exclusiveMinimum": 0, "exclusiveMaximum": 10}`, `0`, false},
+		{"Open interval upper boundary", `{"type": "number", "exclusiveMinimum": 0, "exclusiveMaximum": 10}`, `10`, false},
+		{"Open interval below", `{"type": "number", "exclusiveMinimum": 0, "exclusiveMaximum": 10}`, `-1`, false},
+		{"Open interval above", `{"type": "number", "exclusiveMinimum": 0, "exclusiveMaximum": 10}`, `11`, false},
+		{"Draft-04 open interval lower boundary", `{"minimum": 0, "exclusiveMinimum": true, "maximum": 10, "exclusiveMaximum": true}`, `0`, false},
+		{"Draft-04 open interval upper boundary", `{"minimum": 0, "exclusiveMinimum": true, "maximum": 10, "exclusiveMaximum": true}`, `10`, false},
+		{"Draft-04 open interval inside", `{"minimum": 0, "exclusiveMinimum": true, "maximum": 10, "exclusiveMaximum": true}`, `10e-1`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := v.ValidateJSON(tt.data, tt.schema)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.valid, result.Valid)
+		})
+	}
+}
+
+func TestStringLengthByRune(t *testing.T) {
+	schemaJSON := `{"type": "string", "maxLength": 4}`
+
+	result, err := New().ValidateJSON(`"café"`, schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+
+	result, err = New(WithByteLength(true)).ValidateJSON(`"café"`, schemaJSON)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+
+	err = New().Var("👍", "maxLength=1")
+	assert.NoError(t, err)
+}
+
+func TestValidateJSONContentEncoding(t *testing.T) {
+	v := New()
+	schemaJSON := `{
+		"type": "object",
+		"properties": {
+			"payload": {"type": "string", "contentEncoding": "base64", "contentMediaType": "application/json"}
+		}
+	}`
+
+	tests := []struct {
+		name  string
+		data  string
+		valid bool
+		tag   string
+	}{
+		{"Valid payload", `{"payload": "eyJhIjoxfQ=="}`, true, ""},
+		{"Corrupt base64", `{"payload": "eyJhIjox*Q=="}`, false, "contentEncoding"},
+		{"Decoded content is not JSON", `{"payload": "bm90IGpzb24="}`, false, "contentMediaType"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := v.ValidateJSON(tt.data, schemaJSON)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.valid, result.Valid)
+			if tt.tag != "" && assert.Len(t, result.Errors, 1) {
+				assert.Equal(t, tt.tag, result.Errors[0].Tag)
+				assert.Equal(t, "$.payload", result.Errors[0].Path)
+			}
+		})
+	}
+}
+
+func TestValidateJSONCompare(t *testing.T) {
+	v := New()
+	tests := []struct {
+		name   string
+		schema string
+		data   string
+		valid  bool
+	}{
+		{"gt satisfied", `{"compare": {"op": "gt", "value": 0}}`, `3`, true},
+		{"gt violated", `{"compare": {"op": "gt", "value": 0}}`, `-1`, false},
+		{"lt satisfied", `{"compare": {"op": "lt", "value": 100}}`, `99.5`, true},
+		{"lt violated", `{"compare": {"op": "lt", "value": 100}}`, `100`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := v.ValidateJSON(tt.data, tt.schema)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.valid, result.Valid)
+		})
+	}
+}
+
+func TestValidateJSONPrefixItems(t *testing.T) {
+	v := New()
+	schemaJSON := `{
+		"type": "array",
+		"prefixItems": [{"type": "string"}, {"type": "number"}],
+		"items": false
+	}`
+
+	tests := []struct {
+		name  string
+		data  string
+		valid bool
+		tag   string
+		path  string
+	}{
+		{"Valid tuple", `["a", 1]`, true, "", ""},
+		{"Shorter tuple", `["a"]`, true, "", ""},
+		{"Wrong positional type", `[1, 1]`, false, "type", "$[0]"},
+		{"Extra item rejected", `["a", 1, 2]`, false, "items", "$"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := v.ValidateJSON(tt.data, schemaJSON)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.valid, result.Valid)
+			if tt.tag != "" && assert.Len(t, result.Errors, 1) {
+				assert.Equal(t, tt.tag, result.Errors[0].Tag)
+				assert.Equal(t, tt.path, result.Errors[0].Path)
+			}
+		})
+	}
+
+	t.Run("Items schema after prefix", func(t *testing.T) {
+		schemaJSON := `{"prefixItems": [{"type": "string"}], "items": {"type": "number"}}`
+		result, err := v.ValidateJSON(`["a", 1, 2]`, schemaJSON)
+		assert.NoError(t, err)
+		assert.True(t, result.Valid)
+
+		result, err = v.ValidateJSON(`["a", 1, "b"]`, schemaJSON)
+		assert.NoError(t, err)
+		assert.False(t, result.Valid)
+	})
+}
+
+func TestBooleanPropertySchemas(t *testing.T) {
+	v := New()
+	schemaJSON := `{
+		"type": "object",
+		"properties": {
+			"anything": true,
+			"forbidden": false
+		}
+	}`
+
+	tests := []struct {
+		name  string
+		data  string
+		valid bool
+	}{
+		{"True schema accepts any value", `{"anything": [1, "x", null]}`, true},
+		{"False schema absent property", `{}`, true},
+		{"False schema rejects present property", `{"forbidden": 1}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := v.ValidateJSON(tt.data, schemaJSON)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.valid, result.Valid)
+			if !tt.valid && assert.Len(t, result.Errors, 1) {
+				assert.Equal(t, "$.forbidden", result.Errors[0].Path)
+				assert.Equal(t, "false_schema", result.Errors[0].Tag)
+			}
+		})
+	}
+
+	t.Run("Schema map path", func(t *testing.T) {
+		schemaMap := map[string]interface{}{
+			"properties": map[string]interface{}{"anything": true, "forbidden": false},
+		}
+		result, err := v.ValidateWithSchema(map[string]interface{}{"anything": 1}, schemaMap, "root")
+		assert.NoError(t, err)
+		assert.True(t, result.Valid)
+
+		result, err = v.ValidateWithSchema(map[string]interface{}{"forbidden": 1}, schemaMap, "root")
+		assert.NoError(t, err)
+		assert.False(t, result.Valid)
+	})
+}
+
+func TestFormatAssertionOptions(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []Option
+		schema    string
+		valid     bool
+		expectErr string
+	}{
+		{"Known format asserted by default", nil, `{"format": "email"}`, false, ""},
+		{"Known format annotation only", []Option{WithFormatAssertion(false)}, `{"format": "email"}`, true, ""},
+		{"Unknown format fails compile by default", nil, `{"format": "custom-id"}`, false, "unknown format 'custom-id' in strict mode"},
+		{"Unknown format errors in warn mode", []Option{WithValidationMode(schema.ModeWarn)}, `{"format": "custom-id"}`, false, ""},
+		{"Unknown format ignored", []Option{WithUnknownFormatAssertion(false)}, `{"format": "custom-id"}`, true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := New(tt.opts...).ValidateJSON(`"not-an-email"`, tt.schema)
+			if tt.expectErr != "" {
+				assert.ErrorContains(t, err, tt.expectErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.valid, result.Valid)
+		})
+	}
+}
+
+func TestUnknownFormatAtCompile(t *testing.T) {
+	schemaJSON := `{"type": "object", "properties": {"email": {"type": "string", "format": "emial"}}}`
+
+	_, err := New().CompileSchema(schemaJSON)
+	assert.ErrorContains(t, err, "unknown format 'emial' in strict mode")
+
+	// 属性没有值时同样在编译阶段报告
+	_, err = New().ValidateJSON(`{}`, schemaJSON)
+	assert.ErrorContains(t, err, "unknown format 'emial'")
+
+	_, err = New(WithValidationMode(schema.ModeLoose)).CompileSchema(schemaJSON)
+	assert.NoError(t, err)
+	_, err = New(WithUnknownFormatAssertion(false)).CompileSchema(schemaJSON)
+	assert.NoError(t, err)
+
+	// 实例级注册的格式和内置数值格式在编译时可识别
+	v := New()
+	v.RegisterFormat("emial", func(s string) bool { return true })
+	_, err = v.CompileSchema(schemaJSON)
+	assert.NoError(t, err)
+	_, err = New().CompileSchema(`{"type": "number", "format": "latitude"}`)
+	assert.NoError(t, err)
+}
+
+func TestValidateAgainstDef(t *testing.T) {
+	v := New()
+	s, err := schema.Parse(`{
+		"type": "object",
+		"required": ["shipping"],
+		"properties": {"shipping": {"type": "object"}},
+		"$defs": {
+			"Address": {
+				"type": "object",
+				"required": ["street", "city"],
+				"properties": {"street": {"type": "string"}, "city": {"type": "string", "minLength": 2}}
+			}
+		}
+	}`)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		data    interface{}
+		defName string
+		valid   bool
+		errPath string
+	}{
+		{"Valid address", map[string]interface{}{"street": "Main St", "city": "Paris"}, "Address", true, ""},
+		{"Reference form", map[string]interface{}{"street": "Main St", "city": "Paris"}, "#/$defs/Address", true, ""},
+		{"Invalid address", map[string]interface{}{"street": "Main St", "city": "P"}, "Address", false, "$.city"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := v.ValidateAgainstDef(tt.data, s, tt.defName)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.valid, result.Valid)
+			if tt.errPath != "" && assert.Len(t, result.Errors, 1) {
+				assert.Equal(t, tt.errPath, result.Errors[0].Path)
+			}
+		})
+	}
+
+	_, err = v.ValidateAgainstDef(map[string]interface{}{}, s, "Missing")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "definition 'Missing' not found")
+
+	// 定义块不影响根schema验证
+	result, err := v.ValidateAgainst(map[string]interface{}{"shipping": map[string]interface{}{}}, s)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+}
+
+func TestRegisterFormatPerValidator(t *testing.T) {
+	us := New()
+	us.RegisterFormat("phone", func(s string) bool { return len(s) == 12 && strings.HasPrefix(s, "+1") })
+	fr := New()
+	fr.RegisterFormat("phone", func(s string) bool { return len(s) == 12 && strings.HasPrefix(s, "+33") })
+	plain := New()
+
+	schemaJSON := `{"type": "string", "format": "phone"}`
+
+	result, err := us.ValidateJSON(`"+14155550100"`, schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+
+	result, err = fr.ValidateJSON(`"+14155550100"`, schemaJSON)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+
+	result, err = fr.ValidateJSON(`"+33612345678"`, schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+
+	// 未注册该格式的实例不受影响，严格模式下编译即失败
+	_, err = plain.ValidateJSON(`"+14155550100"`, schemaJSON)
+	assert.ErrorContains(t, err, "unknown format 'phone'")
+}
+
+func TestStructCrossFieldComparison(t *testing.T) {
+	type Booking struct {
+		StartDate time.Time
+		EndDate   time.Time `validate:"gtfield=StartDate"`
+		Guests    int
+		Beds      int `validate:"gefield=Guests"`
+	}
+
+	v := New()
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	err := v.Struct(Booking{StartDate: start, EndDate: start.AddDate(0, 0, 3), Guests: 2, Beds: 2})
+	assert.NoError(t, err)
+
+	err = v.Struct(Booking{StartDate: start, EndDate: start.AddDate(0, 0, -1), Guests: 3, Beds: 2})
+	var ve errors.ValidationErrors
+	if assert.True(t, goerrors.As(err, &ve)) && assert.Len(t, ve, 2) {
+		assert.Equal(t, "EndDate", ve[0].Path)
+		assert.Equal(t, "gtfield", ve[0].Tag)
+		assert.Equal(t, "StartDate", ve[0].Param)
+		assert.Equal(t, "field must be greater than StartDate", ve[0].Message)
+		assert.Equal(t, "Beds", ve[1].Path)
+		assert.Equal(t, "gefield", ve[1].Tag)
+	}
+
+	type Broken struct {
+		A int `validate:"eqfield=Missing"`
+	}
+	err = v.Struct(Broken{A: 1})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "eqfield references unknown field 'Missing'")
+}
+
+func TestTupleItemsFormats(t *testing.T) {
+	schemaJSON := `{
+		"type": "array",
+		"items": [
+			{"type": "string", "format": "uuid"},
+			{"type": "string", "format": "date-time"}
+		]
+	}`
+
+	tests := []struct {
+		name    string
+		data    string
+		valid   bool
+		errPath string
+	}{
+		{"Valid tuple", `["123e4567-e89b-12d3-a456-426614174000", "2024-05-01T10:00:00Z"]`, true, ""},
+		{"Invalid date-time slot", `["123e4567-e89b-12d3-a456-426614174000", "2024-13-01"]`, false, "$[1]"},
+		{"Invalid uuid slot", `["not-a-uuid", "2024-05-01T10:00:00Z"]`, false, "$[0]"},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := v.ValidateJSON(tt.data, schemaJSON)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.valid, result.Valid)
+			if tt.errPath != "" && assert.Len(t, result.Errors, 1) {
+				assert.Equal(t, tt.errPath, result.Errors[0].Path)
+				assert.Equal(t, "format", result.Errors[0].Tag)
+			}
+		})
+	}
+
+	// 验证模式需要传递到元组元素的格式验证
+	loose := New(WithValidationMode(schema.ModeLoose))
+	unknownFormat := `{"items": [{"format": "uuid"}, {"format": "custom-slot"}]}`
+	result, err := loose.ValidateJSON(`["123e4567-e89b-12d3-a456-426614174000", "anything"]`, unknownFormat)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+
+	schemaMap := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"format": "uuid"},
+			map[string]interface{}{"format": "custom-slot"},
+		},
+	}
+	mapResult, err := loose.ValidateWithSchema([]interface{}{"123e4567-e89b-12d3-a456-426614174000", "anything"}, schemaMap, "root")
+	assert.NoError(t, err)
+	assert.True(t, mapResult.Valid)
+
+	mapResult, err = New().ValidateWithSchema([]interface{}{"123e4567-e89b-12d3-a456-426614174000", "anything"}, schemaMap, "root")
+	assert.NoError(t, err)
+	assert.False(t, mapResult.Valid)
+}
+
+func TestStructOneOfTag(t *testing.T) {
+	type Plan struct {
+		Seats int    `validate:"oneof=10 20 30"`
+		Tier  string `validate:"oneof=basic pro"`
+	}
+
+	v := New()
+	assert.NoError(t, v.Struct(Plan{Seats: 20, Tier: "pro"}))
+
+	err := v.Struct(Plan{Seats: 15, Tier: "pro"})
+	var ve errors.ValidationErrors
+	if assert.True(t, goerrors.As(err, &ve)) && assert.Len(t, ve, 1) {
+		assert.Equal(t, "Seats", ve[0].Path)
+		assert.Equal(t, "oneof", ve[0].Tag)
+		assert.Equal(t, "value must be one of: 10, 20, 30", ve[0].Message)
+	}
+
+	assert.Error(t, v.Struct(Plan{Seats: 10, Tier: "enterprise"}))
+	assert.NoError(t, v.Var(30, "oneof=10 20 30"))
+}
+
+func TestValidateJSONMaxDecimals(t *testing.T) {
+	v := New()
+	schemaJSON := `{"type": "number", "maxDecimals": 2}`
+
+	for data, valid := range map[string]bool{"19.99": true, "19.999": false, "20": true} {
+		result, err := v.ValidateJSON(data, schemaJSON)
+		assert.NoError(t, err)
+		assert.Equal(t, valid, result.Valid, data)
+	}
+}
+
+func TestStructLenTag(t *testing.T) {
+	type Card struct {
+		Country string            `validate:"len=2"`
+		Digits  []int             `validate:"len=4"`
+		Meta    map[string]string `validate:"len=1"`
+	}
+
+	v := New()
+	assert.NoError(t, v.Struct(Card{Country: "FR", Digits: []int{1, 2, 3, 4}, Meta: map[string]string{"k": "v"}}))
+
+	err := v.Struct(Card{Country: "FRA", Digits: []int{1}, Meta: map[string]string{"k": "v"}})
+	var ve errors.ValidationErrors
+	if assert.True(t, goerrors.As(err, &ve)) && assert.Len(t, ve, 2) {
+		assert.Equal(t, "length must be exactly 2, got 3", ve[0].Message)
+		assert.Equal(t, "Digits", ve[1].Path)
+	}
+}
+
+func TestEmptinessKeywords(t *testing.T) {
+	v := New()
+
+	result, err := v.ValidateJSON(`{}`, `{"type": "object", "nonEmpty": true}`)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+
+	result, err = v.ValidateJSON(`{}`, `{"type": "object", "empty": true}`)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+
+	type Request struct {
+		Labels map[string]string `validate:"nonEmpty"`
+	}
+	assert.Error(t, v.Struct(Request{Labels: map[string]string{}}))
+	assert.NoError(t, v.Struct(Request{Labels: map[string]string{"env": "prod"}}))
+}
+
+func TestStructNumericBoundTags(t *testing.T) {
+	type Person struct {
+		Age int `validate:"gte=18,lte=120"`
+	}
+
+	v := New()
+	assert.NoError(t, v.Struct(Person{Age: 18}))
+	assert.NoError(t, v.Struct(Person{Age: 120}))
+
+	err := v.Struct(Person{Age: 17})
+	var ve errors.ValidationErrors
+	if assert.True(t, goerrors.As(err, &ve)) && assert.Len(t, ve, 1) {
+		assert.Equal(t, "gte", ve[0].Tag)
+		assert.Equal(t, "Age", ve[0].Path)
+	}
+	assert.Error(t, v.Struct(Person{Age: 121}))
+	assert.Error(t, v.Var(5, "gt=5"))
+	assert.NoError(t, v.Var(4.5, "lt=5"))
+}
+
+func TestCollectAnnotations(t *testing.T) {
+	schemaJSON := `{
+		"title": "Account",
+		"type": "object",
+		"properties": {
+			"plan": {"type": "string", "title": "Plan", "default": "free", "readOnly": true}
+		}
+	}`
+
+	v := New(WithCollectAnnotations(true))
+	result, err := v.ValidateJSON(`{"plan": "pro"}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Equal(t, []Annotation{
+		{Path: "$", Keyword: "title", Value: "Account"},
+		{Path: "$.plan", Keyword: "title", Value: "Plan"},
+		{Path: "$.plan", Keyword: "default", Value: "free"},
+		{Path: "$.plan", Keyword: "readOnly", Value: true},
+	}, result.Annotations)
+
+	// 默认不收集注解
+	result, err = New().ValidateJSON(`{"plan": "pro"}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Empty(t, result.Annotations)
+
+	schemaMap := map[string]interface{}{"description": "a counter", "readOnly": true, "type": "number"}
+	mapResult, err := v.ValidateWithSchema(1, schemaMap, "root")
+	assert.NoError(t, err)
+	assert.True(t, mapResult.Valid)
+	assert.Equal(t, []Annotation{
+		{Path: "root", Keyword: "description", Value: "a counter"},
+		{Path: "root", Keyword: "readOnly", Value: true},
+	}, mapResult.Annotations)
+}
+
+func TestStructCharClassTags(t *testing.T) {
+	type Form struct {
+		Username string `validate:"alphanum"`
+		Zip      string `validate:"numeric"`
+	}
+
+	v := New()
+	assert.NoError(t, v.Struct(Form{Username: "alice42", Zip: "12345"}))
+
+	err := v.Struct(Form{Username: "alice_42", Zip: "12345"})
+	var ve errors.ValidationErrors
+	if assert.True(t, goerrors.As(err, &ve)) && assert.Len(t, ve, 1) {
+		assert.Equal(t, "alphanum", ve[0].Tag)
+		assert.Equal(t, "value must contain only letters and digits", ve[0].Message)
+	}
+}
+
+type treeNode struct {
+	Name     string `validate:"required"`
+	Children []*treeNode
+	Parent   *treeNode
+}
+
+func TestStructRecursiveCycles(t *testing.T) {
+	v := New(WithRecursiveValidation(true), WithUntaggedNestedValidation(true))
+
+	root := &treeNode{Name: "root"}
+	child := &treeNode{Name: "child", Parent: root}
+	root.Children = []*treeNode{child}
+	// 循环引用：子节点的子节点指回根节点
+	child.Children = []*treeNode{root}
+	assert.NoError(t, v.Struct(root))
+
+	leaf := &treeNode{Parent: child}
+	child.Children = append(child.Children, leaf)
+	err := v.Struct(root)
+	var ve errors.ValidationErrors
+	if assert.True(t, goerrors.As(err, &ve)) && assert.Len(t, ve, 1) {
+		assert.Equal(t, "Children[0].Children[1].Name", ve[0].Path)
+		assert.Equal(t, "required", ve[0].Tag)
+	}
+}
+
+func TestStructRecursiveUntaggedFields(t *testing.T) {
+	root := &treeNode{Name: "root", Children: []*treeNode{{}}}
+
+	// 默认只递归进入声明了验证标签的字段
+	v := New(WithRecursiveValidation(true))
+	assert.NoError(t, v.Struct(root))
+
+	v = New(WithRecursiveValidation(true), WithUntaggedNestedValidation(true))
+	err := v.Struct(root)
+	var ve errors.ValidationErrors
+	if assert.True(t, goerrors.As(err, &ve)) && assert.Len(t, ve, 1) {
+		assert.Equal(t, "Children[0].Name", ve[0].Path)
+	}
+}
+
+func TestStructSubstringTags(t *testing.T) {
+	type Account struct {
+		Role  string `validate:"required,startswith=ADMIN_"`
+		File  string `validate:"endswith=.json"`
+		Email string `validate:"containssub=@"`
+	}
+
+	v := New()
+	assert.NoError(t, v.Struct(Account{Role: "ADMIN_root", File: "a.json", Email: "a@b.c"}))
+
+	err := v.Struct(Account{Role: "user", File: "a.json", Email: "a@b.c"})
+	var ve errors.ValidationErrors
+	if assert.True(t, goerrors.As(err, &ve)) && assert.Len(t, ve, 1) {
+		assert.Equal(t, "startswith", ve[0].Tag)
+		assert.Equal(t, "value must start with 'ADMIN_'", ve[0].Message)
+	}
+}
+
+func TestRequiredReportsAllMissing(t *testing.T) {
+	v := New()
+	schemaJSON := `{"type": "object", "required": ["name", "email", "age"]}`
+
+	s, err := v.CompileSchema(schemaJSON)
+	assert.NoError(t, err)
+	compiled, err := v.ValidateAgainst(map[string]interface{}{}, s)
+	assert.NoError(t, err)
+
+	schemaMap := map[string]interface{}{
+		"type":     "object",
+		"required": []interface{}{"name", "email", "age"},
+	}
+	raw, err := v.ValidateWithSchema(map[string]interface{}{}, schemaMap, "$")
+	assert.NoError(t, err)
+
+	for _, result := range []*ValidationResult{compiled, raw} {
+		assert.False(t, result.Valid)
+		if assert.Len(t, result.Errors, 3) {
+			for i, field := range []string{"name", "email", "age"} {
+				assert.Equal(t, "$."+field, result.Errors[i].Path)
+				assert.Equal(t, "required", result.Errors[i].Tag)
+				assert.Contains(t, result.Errors[i].Message, field)
+			}
+		}
+	}
+}
+
+func TestJSONFieldNames(t *testing.T) {
+	type Profile struct {
+		Bio string `json:"bio" validate:"minLength=3"`
+	}
+	type User struct {
+		UserName string  `json:"user_name,omitempty" validate:"required"`
+		Age      int     `validate:"minimum=18"`
+		Profile  Profile `json:"profile"`
+	}
+
+	input := User{Age: 10, Profile: Profile{Bio: "x"}}
+
+	err := New(WithRecursiveValidation(true), WithUntaggedNestedValidation(true)).Struct(input)
+	var ve errors.ValidationErrors
+	if assert.True(t, goerrors.As(err, &ve)) && assert.Len(t, ve, 3) {
+		assert.Equal(t, "UserName", ve[0].Path)
+		assert.Equal(t, "Profile.Bio", ve[2].Path)
+	}
+
+	err = New(WithRecursiveValidation(true), WithUntaggedNestedValidation(true), WithJSONFieldNames(true)).Struct(input)
+	if assert.True(t, goerrors.As(err, &ve)) && assert.Len(t, ve, 3) {
+		assert.Equal(t, "user_name", ve[0].Path)
+		assert.Equal(t, "Age", ve[1].Path)
+		assert.Equal(t, "profile.bio", ve[2].Path)
+	}
+}
+
+func TestStructErrorFormattingMode(t *testing.T) {
+	type User struct {
+		Name string `validate:"required"`
+		Age  int    `validate:"minimum=18"`
+	}
+	input := User{Age: 10}
+
+	err := New().Struct(input)
+	assert.Contains(t, err.Error(), "validation failed with the following errors:")
+
+	err = New(WithErrorFormattingMode(errors.FormattingModeSimple)).Struct(input)
+	assert.Equal(t, "field is required; value 10 is less than minimum 18", err.Error())
+
+	err = New(WithErrorFormattingMode(errors.FormattingModeJSON)).Var("ab", "minLength=3")
+	assert.Equal(t, `[{"path":"var","message":"length less than minimum 3","tag":"minLength","param":"3","schema":3}]`, err.Error())
+
+	var ve errors.ValidationErrors
+	assert.True(t, goerrors.As(err, &ve))
+	assert.Len(t, ve, 1)
+}
+
+func TestCompiledPatternPropertiesWithAdditionalProperties(t *testing.T) {
+	v := New()
+	s, err := v.CompileSchema(`{
+		"type": "object",
+		"properties": {"name": {"type": "string"}},
+		"patternProperties": {"^[0-9]+$": {"type": "integer"}},
+		"additionalProperties": false
+	}`)
+	assert.NoError(t, err)
+
+	result, err := v.ValidateAgainst(map[string]interface{}{"name": "list", "0": 1.0, "12": 2.0}, s)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid, "%v", result.Errors)
+
+	result, err = v.ValidateAgainst(map[string]interface{}{"name": "list", "1": "x", "a1": 3.0}, s)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	paths := map[string]string{}
+	for _, e := range result.Errors {
+		paths[e.Path] = e.Tag
+	}
+	assert.Equal(t, map[string]string{"$.1": "type", "$.a1": "additionalProperties"}, paths)
+}
+
+type embeddedBase struct {
+	ID string `validate:"required"`
+}
+
+type embeddedAudit struct {
+	CreatedBy string `validate:"minLength=2"`
+}
+
+func TestStructEmbeddedFields(t *testing.T) {
+	type Document struct {
+		embeddedBase
+		*embeddedAudit
+		Title string `validate:"required"`
+	}
+	type Article struct {
+		Base  embeddedBase
+		Title string `validate:"required"`
+	}
+
+	v := New()
+	assert.NoError(t, v.Struct(Document{embeddedBase: embeddedBase{ID: "1"}, Title: "t"}))
+
+	err := v.Struct(Document{embeddedAudit: &embeddedAudit{CreatedBy: "a"}, Title: "t"})
+	var ve errors.ValidationErrors
+	if assert.True(t, goerrors.As(err, &ve)) && assert.Len(t, ve, 2) {
+		assert.Equal(t, "ID", ve[0].Path)
+		assert.Equal(t, "required", ve[0].Tag)
+		assert.Equal(t, "CreatedBy", ve[1].Path)
+	}
+
+	// 具名的结构体字段不会被展开
+	assert.NoError(t, v.Struct(Article{Title: "t"}))
+}
+
+func TestValidateJSONByteBounds(t *testing.T) {
+	v := New()
+	result, err := v.ValidateJSON(`"héllo"`, `{"type": "string", "maxLength": 5, "maxBytes": 5}`)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "maxBytes", result.Errors[0].Tag)
+	}
+
+	assert.Error(t, v.Var("日本語", "maxBytes=6"))
+	assert.NoError(t, v.Var("日本語", "maxLength=3,maxBytes=9"))
+}
+
+func TestStructPointerFields(t *testing.T) {
+	type Address struct {
+		City string `validate:"required"`
+	}
+	type Profile struct {
+		Nickname *string  `validate:"required,minLength=2"`
+		Bio      *string  `validate:"maxLength=5"`
+		Address  *Address `validate:"required"`
+	}
+
+	v := New(WithRecursiveValidation(true))
+	nick, empty, short := "neo", "", "x"
+
+	assert.NoError(t, v.Struct(Profile{Nickname: &nick, Address: &Address{City: "Paris"}}))
+
+	tests := []struct {
+		name  string
+		input Profile
+		path  string
+		tag   string
+	}{
+		{"Nil required pointer", Profile{Address: &Address{City: "Paris"}}, "Nickname", "required"},
+		{"Pointer to empty string", Profile{Nickname: &empty, Address: &Address{City: "Paris"}}, "Nickname", "required"},
+		{"Pointed value checked", Profile{Nickname: &short, Address: &Address{City: "Paris"}}, "Nickname", "minLength"},
+		{"Nested pointer struct", Profile{Nickname: &nick, Address: &Address{}}, "Address.City", "required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Struct(tt.input)
+			var ve errors.ValidationErrors
+			if assert.True(t, goerrors.As(err, &ve)) && assert.Len(t, ve, 1) {
+				assert.Equal(t, tt.path, ve[0].Path)
+				assert.Equal(t, tt.tag, ve[0].Tag)
+			}
+		})
+	}
+}
+
+func TestValidateAtRootPath(t *testing.T) {
+	v := New()
+	result, err := v.ValidateJSONAtPath(`{"user": {"name": 1}}`,
+		`{"type": "object", "properties": {"user": {"type": "object", "properties": {"name": {"type": "string"}}, "required": ["email"]}}}`,
+		"body")
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	paths := []string{}
+	for _, e := range result.Errors {
+		paths = append(paths, e.Path)
+	}
+	assert.ElementsMatch(t, []string{"body.user.email", "body.user.name"}, paths)
+
+	type User struct {
+		Name string `validate:"required"`
+	}
+	err = v.StructAtPath(User{}, "body.user")
+	var ve errors.ValidationErrors
+	if assert.True(t, goerrors.As(err, &ve)) && assert.Len(t, ve, 1) {
+		assert.Equal(t, "body.user.Name", ve[0].Path)
+	}
+	assert.NoError(t, v.StructAtPath(User{Name: "a"}, "body.user"))
+}
+
+type tenantTierKey struct{}
+
+func TestValidateJSONCtxContextValues(t *testing.T) {
+	v := New(WithValidationMode(schema.ModeLoose))
+	v.RegisterValidatorMust("tierLimit", func(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+		limits, _ := schemaValue.(map[string]interface{})
+		tier, _ := ctx.Value(tenantTierKey{}).(string)
+		limit, ok := limits[tier].(float64)
+		if !ok {
+			return true, nil
+		}
+		if n, _ := value.(float64); n > limit {
+			return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("value exceeds %s tier limit %v", tier, limit), Tag: "tierLimit"}
+		}
+		return true, nil
+	})
+	schemaJSON := `{"type": "number", "tierLimit": {"free": 10, "pro": 100}}`
+
+	free := context.WithValue(context.Background(), tenantTierKey{}, "free")
+	result, err := v.ValidateJSONCtx(free, `42`, schemaJSON)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "value exceeds free tier limit 10", result.Errors[0].Message)
+	}
+
+	pro := context.WithValue(context.Background(), tenantTierKey{}, "pro")
+	result, err = v.ValidateJSONCtx(pro, `42`, schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+}
+
+func TestWithContextValueRequireContext(t *testing.T) {
+	tierLimit := func(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+		limits, _ := schemaValue.(map[string]interface{})
+		tier, _ := ctx.Value("tenantTier").(string)
+		if n, _ := value.(float64); n > limits[tier].(float64) {
+			return false, &errors.ValidationError{Path: path, Message: fmt.Sprintf("value exceeds %s tier limit", tier), Tag: "tierLimit"}
+		}
+		return true, nil
+	}
+	schemaJSON := `{"type": "number", "requireContext": "tenantTier", "tierLimit": {"free": 10, "pro": 100}}`
+
+	// 验证器级别的上下文值
+	v := New(WithValidationMode(schema.ModeLoose), WithContextValue("tenantTier", "free"))
+	v.RegisterValidatorMust("tierLimit", tierLimit)
+	result, err := v.ValidateJSON(`42`, schemaJSON)
+	assert.NoError(t, err)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "tierLimit", result.Errors[0].Tag)
+	}
+	result, err = v.ValidateJSON(`5`, schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid, "%v", result.Errors)
+
+	// 调用方上下文中的同名键优先
+	result, err = v.ValidateJSONCtx(context.WithValue(context.Background(), "tenantTier", "pro"), `42`, schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid, "%v", result.Errors)
+
+	// 缺少上下文值时 requireContext 报错，依赖该值的规则不会静默通过
+	bare := New(WithValidationMode(schema.ModeLoose))
+	bare.RegisterValidatorMust("tierLimit", tierLimit)
+	result, err = bare.ValidateJSON(`5`, `{"type": "number", "requireContext": ["tenantTier"]}`)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "requireContext", result.Errors[0].Tag)
+		assert.Equal(t, "tenantTier", result.Errors[0].Param)
+	}
+
+	// 严格模式下 requireContext 是已知关键字
+	result, err = New().ValidateJSONCtx(context.WithValue(context.Background(), "tenantTier", "pro"), `5`, `{"type": "number", "requireContext": "tenantTier"}`)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid, "%v", result.Errors)
+}
+
+func TestValidateJSONCtxCancelled(t *testing.T) {
+	v := New(WithValidationMode(schema.ModeLoose))
+	calls := 0
+	v.RegisterValidatorMust("slow", func(ctx context.Context, value interface{}, schemaValue interface{}, path string) (bool, error) {
+		calls++
+		return true, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err := v.ValidateJSONCtx(ctx, `{"a": 1}`, `{"properties": {"a": {"slow": true}}}`)
+	assert.ErrorIs(t, err, context.Canceled)
+	if assert.NotNil(t, result) {
+		assert.False(t, result.Valid)
+		assert.Empty(t, result.Errors)
+	}
+	assert.Equal(t, 0, calls)
+
+	result, err = v.ValidateJSONCtx(context.Background(), `{"a": 1}`, `{"properties": {"a": {"slow": true}}}`)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Equal(t, 1, calls)
+}
+
+func TestPartialResultOnHardError(t *testing.T) {
+	v := New()
+
+	s, err := schema.Parse(`{
+		"type": "object",
+		"required": ["id"],
+		"properties": {
+			"user": {
+				"type": "object",
+				"properties": {"name": {"type": "string", "minLength": 3}},
+				"patternProperties": {"^x-": {}}
+			}
+		}
+	}`)
+	assert.NoError(t, err)
+	assert.NoError(t, s.Compile())
+	// 模拟验证到一半时遇到的非验证错误：替换为无法编译的模式
+	user := s.Compiled.Keywords["properties"].(map[string]*schema.CompiledSchema)["user"]
+	user.Keywords["patternProperties"] = map[string]*schema.CompiledSchema{"(": user.Keywords["patternProperties"].(map[string]*schema.CompiledSchema)["^x-"]}
+
+	result, err := v.ValidateAgainst(map[string]interface{}{"user": map[string]interface{}{"name": "ab"}}, s)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "invalid pattern in patternProperties")
+	}
+	if assert.NotNil(t, result) {
+		assert.False(t, result.Valid)
+		paths := make([]string, 0, len(result.Errors))
+		for _, e := range result.Errors {
+			paths = append(paths, e.Path+" "+e.Tag)
+		}
+		assert.Equal(t, []string{"$.id required", "$.user.name minLength"}, paths)
+	}
+
+	result, err = v.ValidateWithSchema(map[string]interface{}{"b": 1}, map[string]interface{}{
+		"required":   []interface{}{"a"},
+		"properties": map[string]interface{}{"b": "integer"},
+	}, "$")
+	assert.Error(t, err)
+	if assert.NotNil(t, result) && assert.Len(t, result.Errors, 1) {
+		assert.False(t, result.Valid)
+		assert.Equal(t, "required", result.Errors[0].Tag)
+	}
+}
+
+func TestRefProperty(t *testing.T) {
+	schemaJSON := `{
+		"type": "object",
+		"properties": {
+			"nodes": {
+				"type": "array",
+				"items": {
+					"type": "object",
+					"required": ["id"],
+					"properties": {
+						"id": {"type": "integer"},
+						"parentId": {"refProperty": {"path": "/nodes", "property": "id"}}
+					}
+				}
+			}
+		}
+	}`
+	v := New()
+
+	result, err := v.ValidateJSON(`{"nodes": [{"id": 1}, {"id": 2, "parentId": 1}, {"id": 3, "parentId": null}]}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid, "%v", result.Errors)
+
+	result, err = v.ValidateJSON(`{"nodes": [{"id": 1}, {"id": 2, "parentId": 7}]}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "refProperty", result.Errors[0].Tag)
+		assert.Equal(t, "$.nodes[1].parentId", result.Errors[0].Path)
+		assert.Equal(t, "/nodes/id", result.Errors[0].Param)
+	}
+}
+
+func TestValidationResultByPath(t *testing.T) {
+	result := &ValidationResult{
+		Valid: false,
+		Errors: []errors.ValidationError{
+			{Path: "$.name", Message: "length less than minimum 3", Tag: "minLength"},
+			{Path: "$.age", Message: "value must be an integer", Tag: "type"},
+			{Path: "$.name", Message: "value does not match pattern", Tag: "pattern"},
+		},
+	}
+
+	grouped := result.ByPath()
+	assert.Len(t, grouped, 2)
+	if assert.Len(t, grouped["$.name"], 2) {
+		assert.Equal(t, "minLength", grouped["$.name"][0].Tag)
+		assert.Equal(t, "pattern", grouped["$.name"][1].Tag)
+	}
+	assert.Len(t, grouped["$.age"], 1)
+
+	assert.Empty(t, (&ValidationResult{Valid: true}).ByPath())
+}
+
+func TestMaxDocumentBytes(t *testing.T) {
+	v := New(WithMaxDocumentBytes(16))
+	schemaJSON := `{"type": "object"}`
+	small := `{"a": 1}`
+	large := `{"name": "a long enough value"}`
+
+	result, err := v.ValidateJSON(small, schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+
+	result, err = v.ValidateReader(strings.NewReader(small), schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+
+	_, err = v.ValidateJSON(large, schemaJSON)
+	assert.EqualError(t, err, "JSON document exceeds maximum size of 16 bytes")
+
+	_, err = v.ValidateReader(strings.NewReader(large), schemaJSON)
+	assert.EqualError(t, err, "JSON document exceeds maximum size of 16 bytes")
+
+	// 未设置限制时不限制大小
+	result, err = New().ValidateReader(strings.NewReader(large), schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+}
+
+func TestValidateDispatch(t *testing.T) {
+	type Account struct {
+		Name  string   `json:"name"`
+		Age   int      `json:"age"`
+		Tags  []string `json:"tags,omitempty"`
+		Notes string   `json:"-"`
+	}
+	schemaJSON := `{
+		"type": "object",
+		"required": ["name", "age"],
+		"properties": {"name": {"type": "string", "minLength": 2}, "age": {"type": "integer", "minimum": 18}},
+		"additionalProperties": false
+	}`
+
+	v := New()
+	inputs := []struct {
+		name  string
+		valid interface{}
+		bad   interface{}
+	}{
+		{"JSON string", `{"name": "Ann", "age": 30}`, `{"name": "A", "age": 30}`},
+		{"JSON bytes", []byte(`{"name": "Ann", "age": 30}`), []byte(`{"name": "A", "age": 30}`)},
+		{"Struct", Account{Name: "Ann", Age: 30, Notes: "x"}, Account{Name: "A", Age: 30}},
+		{"Struct pointer", &Account{Name: "Ann", Age: 30}, &Account{Name: "A", Age: 30}},
+		{"Decoded map", map[string]interface{}{"name": "Ann", "age": 30.0}, map[string]interface{}{"name": "A", "age": 30.0}},
+		{"Map with Go ints", map[string]interface{}{"name": "Ann", "age": 30}, map[string]interface{}{"name": "A", "age": 30}},
+	}
+
+	for _, tt := range inputs {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := v.Validate(tt.valid, schemaJSON)
+			assert.NoError(t, err)
+			assert.True(t, result.Valid, "%v", result.Errors)
+
+			result, err = v.Validate(tt.bad, schemaJSON)
+			assert.NoError(t, err)
+			assert.False(t, result.Valid)
+			if assert.Len(t, result.Errors, 1) {
+				assert.Equal(t, "$.name", result.Errors[0].Path)
+				assert.Equal(t, "minLength", result.Errors[0].Tag)
+			}
+		})
+	}
+
+	result, err := v.Validate([]int{1, 2}, `{"type": "array", "items": {"type": "integer"}, "maxItems": 1}`)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+
+	result, err = v.Validate(map[string]string{"name": "A"}, `{"type": "object", "properties": {"name": {"minLength": 2}}}`)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+
+	_, err = v.Validate(struct{ F func() }{}, schemaJSON)
+	assert.Error(t, err)
+}
+
+func TestAdditionalItemsTuple(t *testing.T) {
+	v := New()
+	schemaJSON := `{"type": "array", "items": [{"type": "string"}, {"type": "integer"}], "additionalItems": false}`
+
+	result, err := v.ValidateJSON(`["a", 1]`, schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+
+	result, err = v.ValidateJSON(`["a", 1, true]`, schemaJSON)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	if assert.Len(t, result.Errors, 1) {
+		e := result.Errors[0]
+		assert.Equal(t, "additionalItems", e.Tag)
+		assert.Equal(t, "additional items not allowed at index 2", e.Message)
+		assert.Equal(t, "2", e.Param)
+		assert.Equal(t, "/additionalItems", e.SchemaPath)
+	}
+
+	// 映射形式的 schema 结果一致
+	var schemaMap map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(schemaJSON), &schemaMap))
+	mapResult, err := v.ValidateWithSchema([]interface{}{"a", 1.0, true}, schemaMap, "$")
+	assert.NoError(t, err)
+	assert.False(t, mapResult.Valid)
+	if assert.Len(t, mapResult.Errors, 1) {
+		assert.Equal(t, "additional items not allowed at index 2", mapResult.Errors[0].Message)
+	}
+
+	result, err = v.ValidateJSON(`["a", 1, true, false]`, `{"items": [{"type": "string"}], "additionalItems": {"type": "boolean"}}`)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "$[1]", result.Errors[0].Path)
+		assert.Equal(t, "/additionalItems/type", result.Errors[0].SchemaPath)
+	}
+}
+
+func TestValidateJSONAllowedProperties(t *testing.T) {
+	v := New()
+	schemaJSON := `{"type": "object", "allowedProperties": ["a", "b"]}`
+
+	result, err := v.ValidateJSON(`{"a": 1, "b": {"nested": true}}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+
+	result, err = v.ValidateJSON(`{"a": 1, "extra": 2}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "$.extra", result.Errors[0].Path)
+		assert.Equal(t, "allowedProperties", result.Errors[0].Tag)
+	}
+}
+
+func TestStructToValue(t *testing.T) {
+	type Address struct {
+		City string `json:"city"`
+	}
+	type User struct {
+		UserName string   `json:"user_name"`
+		Age      int      `json:"age"`
+		Nickname string   `json:"nickname,omitempty"`
+		Password string   `json:"-"`
+		Address  *Address `json:"address,omitempty"`
+		Score    float64
+	}
+
+	value, err := StructToValue(User{UserName: "ann", Age: 30, Password: "secret", Address: &Address{City: "Oslo"}, Score: 1.5})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"user_name": "ann",
+		"age":       json.Number("30"),
+		"address":   map[string]interface{}{"city": "Oslo"},
+		"Score":     json.Number("1.5"),
+	}, value)
+
+	v := New()
+	result, err := v.ValidateValue(value, `{
+		"type": "object",
+		"required": ["user_name", "age"],
+		"properties": {"user_name": {"type": "string"}, "age": {"type": "integer", "minimum": 18}, "address": {"required": ["city"]}},
+		"additionalProperties": false
+	}`)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "$.Score", result.Errors[0].Path)
+		assert.Equal(t, "additionalProperties", result.Errors[0].Tag)
+	}
+
+	_, err = StructToValue(struct{ C chan int }{})
+	assert.Error(t, err)
+}
+
+func TestCoerceTypes(t *testing.T) {
+	schemaJSON := `{"type": "object", "properties": {"age": {"type": "integer", "minimum": 18}, "active": {"type": "boolean"}}}`
+	query := `{"age": "30", "active": "true"}`
+
+	result, err := New().ValidateJSON(query, schemaJSON)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+
+	v := New(WithCoerceTypes(true))
+	result, err = v.ValidateJSON(query, schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid, "%v", result.Errors)
+
+	result, err = v.ValidateJSON(`{"age": "12", "active": "maybe"}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	tags := map[string]string{}
+	for _, e := range result.Errors {
+		tags[e.Path] = e.Tag
+	}
+	assert.Equal(t, map[string]string{"$.age": "minimum", "$.active": "type"}, tags)
+
+	assert.NoError(t, v.Var("30", "type=integer"))
+	assert.Error(t, New().Var("30", "type=integer"))
+}
+
+func TestExtends(t *testing.T) {
+	schemaJSON := `{
+		"type": "object",
+		"extends": {"type": "object", "required": ["id"], "properties": {"id": {"type": "integer"}}},
+		"required": ["name"],
+		"properties": {"name": {"type": "string"}}
+	}`
+
+	v := New()
+	result, err := v.ValidateJSON(`{"id": 1, "name": "ann"}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid, "%v", result.Errors)
+
+	result, err = v.ValidateJSON(`{"name": "ann"}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "extends", result.Errors[0].Tag)
+		assert.Contains(t, result.Errors[0].Message, "required")
+	}
+
+	result, err = v.ValidateJSON(`{"id": "x", "name": "ann"}`, `{"extends": [{"required": ["id"]}, {"properties": {"id": {"type": "integer"}}}]}`)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+}
+
+func TestRootPath(t *testing.T) {
+	schemaJSON := `{"type": "object", "properties": {"user": {"type": "object", "properties": {"tags": {"type": "array", "items": {"type": "string"}}}}}}`
+	data := `{"user": {"tags": ["a", 1]}}`
+
+	result, err := New().ValidateJSON(data, schemaJSON)
+	assert.NoError(t, err)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "$.user.tags[1]", result.Errors[0].Path)
+	}
+
+	v := New(WithRootPath("body"))
+	result, err = v.ValidateJSON(data, schemaJSON)
+	assert.NoError(t, err)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "body.user.tags[1]", result.Errors[0].Path)
+	}
+
+	s, err := schema.Parse(schemaJSON)
+	assert.NoError(t, err)
+	result, err = v.ValidateAgainst(map[string]interface{}{"user": "x"}, s)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	for _, e := range result.Errors {
+		assert.Equal(t, "body.user", e.Path)
+	}
+
+	type Inner struct {
+		Name string `validate:"required"`
+	}
+	type Outer struct {
+		Age   int `validate:"minimum=18"`
+		Inner Inner
+	}
+	err = New(WithRootPath("body"), WithRecursiveValidation(true), WithUntaggedNestedValidation(true)).Struct(Outer{Age: 3})
+	var ve errors.ValidationErrors
+	if assert.ErrorAs(t, err, &ve) {
+		paths := make([]string, 0, len(ve))
+		for _, e := range ve {
+			paths = append(paths, e.Path)
+		}
+		assert.ElementsMatch(t, []string{"body.Age", "body.Inner.Name"}, paths)
+	}
+
+	err = New().Struct(Outer{Age: 3, Inner: Inner{Name: "x"}})
+	if assert.ErrorAs(t, err, &ve) {
+		assert.Equal(t, "Age", ve[0].Path)
+	}
+}
+
+func TestNullable(t *testing.T) {
+	v := New()
+
+	result, err := v.ValidateJSON(`{"nickname": null}`, `{"type": "object", "properties": {"nickname": {"type": "string", "nullable": true}}}`)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid, "%v", result.Errors)
+
+	result, err = v.ValidateJSON(`{"nickname": null}`, `{"type": "object", "properties": {"nickname": {"type": "string"}}}`)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "$.nickname", result.Errors[0].Path)
+		assert.Equal(t, "type", result.Errors[0].Tag)
+	}
+
+	result, err = v.ValidateJSON(`{"nickname": 1}`, `{"type": "object", "properties": {"nickname": {"type": "string", "nullable": true}}}`)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+
+	_, err = v.ValidateJSON(`null`, `{"type": "string", "nullable": "yes"}`)
+	assert.Error(t, err)
+
+	// nullable 与其他关键字组合时，null 跳过其余约束，非 null 值仍受约束
+	tests := []struct {
+		name       string
+		schemaJSON string
+		invalid    string
+	}{
+		{"With maxLength", `{"type": "string", "nullable": true, "maxLength": 5}`, `"toolong"`},
+		{"With pattern", `{"type": "string", "nullable": true, "pattern": "^[a-z]+$"}`, `"ABC"`},
+		{"With format", `{"type": "string", "nullable": true, "format": "email"}`, `"nope"`},
+		{"With minimum", `{"type": "number", "nullable": true, "minimum": 1}`, `0`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := v.ValidateJSON(`null`, tt.schemaJSON)
+			assert.NoError(t, err)
+			assert.True(t, result.Valid, "%v", result.Errors)
+
+			result, err = v.ValidateJSON(tt.invalid, tt.schemaJSON)
+			assert.NoError(t, err)
+			assert.False(t, result.Valid)
+
+			var schemaMap map[string]interface{}
+			assert.NoError(t, json.Unmarshal([]byte(tt.schemaJSON), &schemaMap))
+			result, err = v.ValidateWithSchema(nil, schemaMap, "$")
+			assert.NoError(t, err)
+			assert.True(t, result.Valid, "%v", result.Errors)
+		})
+	}
+
+	result, err = v.ValidateJSON(`null`, `{"type": "string", "maxLength": 5}`)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+}
+
+func TestPastFutureDateTime(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	v := New(WithClock(func() time.Time { return now }))
+	schemaJSON := `{"type": "object", "properties": {"createdAt": {"type": "string", "pastDateTime": true}, "expiresAt": {"type": "string", "futureDateTime": true}}}`
+
+	result, err := v.ValidateJSON(`{"createdAt": "2024-05-01T00:00:00Z", "expiresAt": "2024-07-01T00:00:00Z"}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid, "%v", result.Errors)
+
+	result, err = v.ValidateJSON(`{"createdAt": "2024-07-01T00:00:00Z", "expiresAt": "2024-05-01T00:00:00Z"}`, schemaJSON)
+	assert.NoError(t, err)
+	tags := map[string]string{}
+	for _, e := range result.Errors {
+		tags[e.Path] = e.Tag
+	}
+	assert.Equal(t, map[string]string{"$.createdAt": "pastDateTime", "$.expiresAt": "futureDateTime"}, tags)
+
+	type Token struct {
+		ExpiresAt string `validate:"futureDateTime"`
+	}
+	assert.NoError(t, v.Struct(Token{ExpiresAt: "2024-06-01T12:00:01Z"}))
+	assert.Error(t, v.Struct(Token{ExpiresAt: "2024-06-01T11:59:59Z"}))
+}
+
+func TestDateFormat(t *testing.T) {
+	v := New()
+	schemaJSON := `{"type": "object", "properties": {"birthday": {"type": "string", "dateFormat": "2006/01/02"}}}`
+
+	result, err := v.ValidateJSON(`{"birthday": "1990/12/31"}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid, "%v", result.Errors)
+
+	result, err = v.ValidateJSON(`{"birthday": "31.12.1990"}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "$.birthday", result.Errors[0].Path)
+		assert.Equal(t, "dateFormat", result.Errors[0].Tag)
+	}
+
+	type Person struct {
+		Birthday string `validate:"dateFormat=2006/01/02"`
+	}
+	assert.NoError(t, v.Struct(Person{Birthday: "1990/12/31"}))
+	assert.Error(t, v.Struct(Person{Birthday: "1990-12-31"}))
+}
+
+func TestPropertyCountBounds(t *testing.T) {
+	v := New()
+
+	result, err := v.ValidateJSON(`{"a": 1}`, `{"type": "object", "minProperties": 2}`)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "minProperties", result.Errors[0].Tag)
+		assert.Equal(t, "$", result.Errors[0].Path)
+	}
+
+	result, err = v.ValidateJSON(`{"a": 1, "b": 2}`, `{"type": "object", "minProperties": 2}`)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid, "%v", result.Errors)
+
+	result, err = v.ValidateJSON(`{"a": 1, "b": 2}`, `{"type": "object", "maxProperties": 1}`)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+
+	_, err = v.ValidateJSON(`{}`, `{"type": "object", "minProperties": "2"}`)
+	assert.Error(t, err)
+}
+
+func TestVarSliceItemCount(t *testing.T) {
+	v := New()
+	tag := "minItems=1,maxItems=3"
+
+	assert.NoError(t, v.Var([]interface{}{"a"}, tag))
+	assert.NoError(t, v.Var([]interface{}{"a", "b", "c"}, tag))
+
+	err := v.Var([]interface{}{}, tag)
+	var ve errors.ValidationErrors
+	if assert.ErrorAs(t, err, &ve) && assert.Len(t, ve, 1) {
+		assert.Equal(t, "minItems", ve[0].Tag)
+		assert.Equal(t, "1", ve[0].Param)
+	}
+
+	err = v.Var([]interface{}{1, 2, 3, 4}, tag)
+	if assert.ErrorAs(t, err, &ve) && assert.Len(t, ve, 1) {
+		assert.Equal(t, "maxItems", ve[0].Tag)
+	}
+}
+
+func TestStructMapPropertyCount(t *testing.T) {
+	type Config struct {
+		Labels map[string]string `validate:"minProperties=1,maxProperties=2"`
+	}
+	v := New()
+
+	assert.NoError(t, v.Struct(Config{Labels: map[string]string{"env": "prod"}}))
+	assert.NoError(t, v.Struct(Config{Labels: map[string]string{"env": "prod", "team": "core"}}))
+
+	tests := []struct {
+		name      string
+		labels    map[string]string
+		expectTag string
+		param     string
+	}{
+		{"Too few properties", map[string]string{}, "minProperties", "1"},
+		{"Too many properties", map[string]string{"a": "1", "b": "2", "c": "3"}, "maxProperties", "2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Struct(Config{Labels: tt.labels})
+			var ve errors.ValidationErrors
+			if assert.ErrorAs(t, err, &ve) && assert.Len(t, ve, 1) {
+				assert.Equal(t, tt.expectTag, ve[0].Tag)
+				assert.Equal(t, tt.param, ve[0].Param)
+				assert.Equal(t, "Labels", ve[0].Path)
+			}
+		})
+	}
+}
+
+func TestPropertiesPatternPropertiesAdditionalProperties(t *testing.T) {
+	schemaJSON := `{
+		"type": "object",
+		"properties": {"id": {"type": "integer"}},
+		"patternProperties": {"^x-": {"type": "string"}},
+		"additionalProperties": false
+	}`
+	v := New()
+
+	result, err := v.ValidateJSON(`{"id": 1, "x-trace": "abc"}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid, "%v", result.Errors)
+
+	result, err = v.ValidateJSON(`{"id": 1, "x-trace": 2, "extra": true}`, schemaJSON)
+	assert.NoError(t, err)
+	tags := map[string]string{}
+	for _, e := range result.Errors {
+		tags[e.Path] = e.Tag
+	}
+	assert.Equal(t, map[string]string{"$.x-trace": "type", "$.extra": "additionalProperties"}, tags)
+
+	var schemaMap map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(schemaJSON), &schemaMap))
+	result, err = v.ValidateWithSchema(map[string]interface{}{"id": 1, "x-trace": "abc"}, schemaMap, "$")
+	assert.NoError(t, err)
+	assert.True(t, result.Valid, "%v", result.Errors)
+	result, err = v.ValidateWithSchema(map[string]interface{}{"id": 1, "extra": true}, schemaMap, "$")
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+}
+
+func TestCompiledDependencies(t *testing.T) {
+	schemaJSON := `{
+		"type": "object",
+		"dependencies": {
+			"creditCard": ["billingAddress"],
+			"shipping": {"required": ["address"]}
+		}
+	}`
+	v := New()
+
+	result, err := v.ValidateJSON(`{"creditCard": "4111", "billingAddress": "x", "shipping": true, "address": "y"}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid, "%v", result.Errors)
+
+	result, err = v.ValidateJSON(`{"creditCard": "4111", "shipping": true}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	if assert.Len(t, result.Errors, 2) {
+		assert.Equal(t, "dependencies", result.Errors[0].Tag)
+		assert.Equal(t, "billingAddress", result.Errors[0].Param)
+		assert.Equal(t, "required", result.Errors[1].Tag)
+		assert.Equal(t, "$.address", result.Errors[1].Path)
+		assert.Equal(t, "/dependencies/shipping/required", result.Errors[1].SchemaPath)
+	}
+}
+
+func TestRequiredIfMatch(t *testing.T) {
+	schemaJSON := `{"type": "object", "requiredIfMatch": {"field": "email", "pattern": "@corp\\.com$", "require": ["employeeId"]}}`
+	v := New()
+
+	result, err := v.ValidateJSON(`{"email": "ann@corp.com"}`, schemaJSON)
+	assert.NoError(t, err)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "$.employeeId", result.Errors[0].Path)
+		assert.Equal(t, "requiredIfMatch", result.Errors[0].Tag)
+	}
+
+	result, err = v.ValidateJSON(`{"email": "ann@corp.com", "employeeId": "E1"}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid, "%v", result.Errors)
+
+	result, err = v.ValidateJSON(`{"email": "ann@example.com"}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid, "%v", result.Errors)
+
+	// 与 required 一样报告每个缺失的属性
+	multiJSON := `{"type": "object", "requiredIfMatch": {"field": "email", "pattern": "@corp\\.com$", "require": ["employeeId", "costCenter"]}}`
+	result, err = v.ValidateJSON(`{"email": "ann@corp.com"}`, multiJSON)
+	assert.NoError(t, err)
+	if assert.Len(t, result.Errors, 2) {
+		assert.Equal(t, "$.employeeId", result.Errors[0].Path)
+		assert.Equal(t, "$.costCenter", result.Errors[1].Path)
+	}
+
+	_, err = v.ValidateJSON(`{"email": "ann@corp.com"}`, `{"requiredIfMatch": {"field": "email", "pattern": "(", "require": ["id"]}}`)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "invalid pattern in requiredIfMatch")
+	}
+}
+
+func TestAdditionalPropertiesHonorsPatternProperties(t *testing.T) {
+	schemaJSON := `{"type": "object", "patternProperties": {"^x-": {"type": "string"}}, "additionalProperties": false}`
+	v := New()
+
+	result, err := v.ValidateJSON(`{"x-custom": "1"}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid, "%v", result.Errors)
+
+	result, err = v.ValidateJSON(`{"x-custom": "1", "custom": "2"}`, schemaJSON)
+	assert.NoError(t, err)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "$.custom", result.Errors[0].Path)
+		assert.Equal(t, "additionalProperties", result.Errors[0].Tag)
+	}
+}
+
+func TestAdditionalPropertiesSchema(t *testing.T) {
+	schemaJSON := `{"type": "object", "properties": {"id": {"type": "integer"}}, "additionalProperties": {"type": "string"}}`
+	v := New()
+
+	result, err := v.ValidateJSON(`{"id": 1, "label": "a"}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid, "%v", result.Errors)
+
+	result, err = v.ValidateJSON(`{"id": 1, "label": "a", "count": 3}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "$.count", result.Errors[0].Path)
+		assert.Equal(t, "type", result.Errors[0].Tag)
+		assert.Equal(t, "/additionalProperties/type", result.Errors[0].SchemaPath)
+	}
+}
+
+func TestUniqueBy(t *testing.T) {
+	schemaJSON := `{"type": "array", "items": {"type": "object"}, "uniqueBy": {"property": "email", "caseInsensitive": true}}`
+	v := New()
+
+	result, err := v.ValidateJSON(`[{"email": "ann@x.com"}, {"email": "bob@x.com"}]`, schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid, "%v", result.Errors)
+
+	result, err = v.ValidateJSON(`[{"email": "ann@x.com"}, {"email": "ANN@x.com"}]`, schemaJSON)
+	assert.NoError(t, err)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "uniqueBy", result.Errors[0].Tag)
+		assert.Equal(t, "$[1].email", result.Errors[0].Path)
+	}
+}
+
+func TestCoverage(t *testing.T) {
+	schemaJSON := `{
+		"type": "object",
+		"required": ["age"],
+		"properties": {
+			"age": {"type": "integer", "minimum": 18},
+			"nickname": {"type": "string", "minLength": 2}
+		}
+	}`
+
+	result, err := New(WithCoverage(true)).ValidateJSON(`{"age": 30}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+	for _, location := range []string{"/type", "/required", "/properties", "/properties/age/type", "/properties/age/minimum"} {
+		assert.True(t, result.Coverage[location], location)
+	}
+	// nickname 不存在，其约束从未执行
+	assert.False(t, result.Coverage["/properties/nickname/minLength"])
+	assert.False(t, result.Coverage["/properties/nickname/type"])
+
+	result, err = New().ValidateJSON(`{"age": 30}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.Nil(t, result.Coverage)
+}
+
+func TestMaxPatternLength(t *testing.T) {
+	long := strings.Repeat("a", 20)
+	schemaJSON := fmt.Sprintf(`{"type": "object", "properties": {"code": {"type": "string", "pattern": "^%s$"}}}`, long)
+
+	v := New(WithMaxPatternLength(16))
+	_, err := v.ValidateJSON(`{"code": "a"}`, schemaJSON)
+	assert.ErrorContains(t, err, "pattern exceeds maximum length of 16")
+
+	_, err = v.CompileSchema(`{"patternProperties": {"` + long + `": {"type": "string"}}}`)
+	assert.Error(t, err)
+
+	result, err := v.ValidateJSON(`{"code": "abc"}`, `{"type": "object", "properties": {"code": {"type": "string", "pattern": "^[a-z]+$"}}}`)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+
+	result, err = New().ValidateJSON(`{"code": "a"}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+}
+
+func TestImplicitObjectType(t *testing.T) {
+	schemaJSON := `{"properties": {"name": {"type": "string"}}}`
+
+	result, err := New().ValidateJSON(`"hello"`, schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid, "%v", result.Errors)
+
+	v := New(WithImplicitObjectType(true))
+	result, err = v.ValidateJSON(`"hello"`, schemaJSON)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "properties", result.Errors[0].Tag)
+		assert.Equal(t, "value must be an object", result.Errors[0].Message)
+	}
+
+	result, err = v.ValidateJSON(`{"name": "ann"}`, schemaJSON)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid, "%v", result.Errors)
+
+	// 显式声明其他类型时不受影响
+	result, err = v.ValidateJSON(`"hello"`, `{"type": "string", "properties": {"name": {"type": "string"}}}`)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid, "%v", result.Errors)
+
+	var schemaMap map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(schemaJSON), &schemaMap))
+	result, err = v.ValidateWithSchema("hello", schemaMap, "$")
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+}
+
+func TestErrorLimit(t *testing.T) {
+	schemaJSON := `{"type": "array", "items": {"type": "object", "properties": {"id": {"type": "integer"}, "name": {"type": "string"}}, "required": ["id", "name"]}}`
+	items := make([]string, 50)
+	for i := range items {
+		items[i] = `{"id": "x", "name": 1}`
+	}
+	jsonData := "[" + strings.Join(items, ",") + "]"
+
+	unlimited, err := New().ValidateJSON(jsonData, schemaJSON)
+	assert.NoError(t, err)
+	assert.Len(t, unlimited.Errors, 100)
+
+	for _, limit := range []int{1, 5, 99, 100} {
+		result, err := New(WithErrorLimit(limit)).ValidateJSON(jsonData, schemaJSON)
+		assert.NoError(t, err)
+		assert.False(t, result.Valid)
+		assert.Len(t, result.Errors, limit, "limit %d", limit)
+	}
+
+	result, err := New(WithErrorLimit(500)).ValidateJSON(jsonData, schemaJSON)
+	assert.NoError(t, err)
+	assert.Len(t, result.Errors, 100)
+
+	schemaMap := map[string]interface{}{"type": "object", "required": []interface{}{"a", "b", "c", "d", "e"}}
+	result, err = New(WithErrorLimit(3)).ValidateWithSchema(map[string]interface{}{}, schemaMap, "$")
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Len(t, result.Errors, 3)
+}
+
+func TestValid(t *testing.T) {
+	schemaJSON := `{"type": "object", "properties": {"name": {"type": "string", "minLength": 2}, "age": {"type": "integer", "minimum": 0}}, "required": ["name"]}`
+	tests := []struct {
+		name     string
+		jsonData string
+	}{
+		{"valid", `{"name": "ann", "age": 30}`},
+		{"missing required", `{"age": 30}`},
+		{"many failures", `{"name": "a", "age": -1, "extra": true}`},
+		{"wrong type", `[]`},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := v.ValidateJSON(tt.jsonData, schemaJSON)
+			assert.NoError(t, err)
+			valid, err := v.Valid(tt.jsonData, schemaJSON)
+			assert.NoError(t, err)
+			assert.Equal(t, result.Valid, valid)
+		})
+	}
+
+	_, err := v.Valid(`{`, schemaJSON)
+	assert.Error(t, err)
+	_, err = v.Valid(`{}`, `{"type": `)
+	assert.Error(t, err)
+}
+
+func TestStrictTypes(t *testing.T) {
+	schemaJSON := `{"type": "object", "properties": {"age": {"type": "integer", "minimum": 18}}}`
+	tests := []struct {
+		name        string
+		jsonData    string
+		strictValid bool
+		looseValid  bool
+	}{
+		{"Integer literal", `{"age": 30}`, true, true},
+		{"Decimal point", `{"age": 30.0}`, false, true},
+		{"Exponent", `{"age": 3e1}`, false, true},
+		{"Fraction", `{"age": 30.5}`, false, false},
+		{"Integer below minimum", `{"age": 10}`, false, false},
+	}
+
+	strict := New(WithStrictTypes(true))
+	loose := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := strict.ValidateJSON(tt.jsonData, schemaJSON)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.strictValid, result.Valid, "strict: %v", result.Errors)
+
+			result, err = loose.ValidateJSON(tt.jsonData, schemaJSON)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.looseValid, result.Valid, "loose: %v", result.Errors)
+		})
+	}
+
+	result, err := strict.ValidateJSON(`30.0`, `{"type": "number"}`)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+
+	_, err = strict.ValidateJSON(`{"age": 30} {}`, schemaJSON)
+	assert.Error(t, err)
+}
+
+// testDecimal 是测试用的十进制金额类型，以分为单位保存
+type testDecimal struct {
+	cents int64
+}
+
+func (d testDecimal) String() string {
+	return fmt.Sprintf("%d.%02d", d.cents/100, d.cents%100)
+}
+
+// parseTestDecimal 解析最多两位小数的非负金额
+func parseTestDecimal(n json.Number) (interface{}, error) {
+	whole, frac, _ := strings.Cut(n.String(), ".")
+	if len(frac) > 2 {
+		return nil, fmt.Errorf("too many decimal places")
+	}
+	units, err := strconv.ParseInt(whole+(frac + "00")[:2], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return testDecimal{cents: units}, nil
+}
+
+func TestNumberParser(t *testing.T) {
+	v := New(WithNumberParser(parseTestDecimal))
+	for _, op := range []string{"eq", "gt", "ge", "lt", "le"} {
+		builtin := v.GetComparator(op)
+		cmp := map[string]func(a, b int64) bool{
+			"eq": func(a, b int64) bool { return a == b },
+			"gt": func(a, b int64) bool { return a > b },
+			"ge": func(a, b int64) bool { return a >= b },
+			"lt": func(a, b int64) bool { return a < b },
+			"le": func(a, b int64) bool { return a <= b },
+		}[op]
+		v.RegisterComparatorMust(op, func(a, b interface{}) bool {
+			da, okA := a.(testDecimal)
+			db, okB := b.(testDecimal)
+			if okA && okB {
+				return cmp(da.cents, db.cents)
+			}
+			return builtin(a, b)
+		})
+	}
+
+	schemaJSON := `{"type": "object", "properties": {
+		"price": {"type": "number", "minimum": 10, "exclusiveMaximum": 100.5},
+		"discount": {"type": "number", "compare": {"op": "gt", "value": 0}}
+	}}`
+	tests := []struct {
+		name     string
+		jsonData string
+		valid    bool
+		errTag   string
+	}{
+		{"Within bounds", `{"price": 10.00, "discount": 0.01}`, true, ""},
+		{"Below minimum", `{"price": 9.99}`, false, "minimum"},
+		{"At exclusive maximum", `{"price": 100.50}`, false, "exclusiveMaximum"},
+		{"Just below exclusive maximum", `{"price": 100.49}`, true, ""},
+		{"Compare gt fails", `{"discount": 0}`, false, "compare"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := v.ValidateJSON(tt.jsonData, schemaJSON)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.valid, result.Valid, "%v", result.Errors)
+			if !tt.valid && assert.Len(t, result.Errors, 1) {
+				assert.Equal(t, tt.errTag, result.Errors[0].Tag)
+				assert.IsType(t, testDecimal{}, result.Errors[0].Value)
+			}
+		})
+	}
+
+	_, err := v.ValidateJSON(`{"price": 10.001}`, schemaJSON)
+	assert.ErrorContains(t, err, "failed to parse number 10.001")
+
+	// enum、const、uniqueItems 通过 eq 比较器比较，multipleOf 按十进制精确计算
+	keywordTests := []struct {
+		name       string
+		schemaJSON string
+		jsonData   string
+		valid      bool
+	}{
+		{"Enum member", `{"enum": [1, 2.5]}`, `2.50`, true},
+		{"Enum non-member", `{"enum": [1, 2.5]}`, `3`, false},
+		{"Const equal", `{"const": 1}`, `1.00`, true},
+		{"Const not equal", `{"const": 1}`, `1.01`, false},
+		{"Nested const", `{"const": {"price": 9.99}}`, `{"price": 9.99}`, true},
+		{"Multiple of cents", `{"multipleOf": 0.05}`, `0.15`, true},
+		{"Not a multiple", `{"multipleOf": 0.05}`, `0.17`, false},
+		{"Unique items", `{"uniqueItems": true}`, `[1, 1.5, 2]`, true},
+		{"Duplicate items", `{"uniqueItems": true}`, `[1, 1.5, 1.00]`, false},
+	}
+	for _, tt := range keywordTests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := v.ValidateJSON(tt.jsonData, tt.schemaJSON)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.valid, result.Valid, "%v", result.Errors)
+		})
+	}
+}
+
+func TestCompiledKeywordOrder(t *testing.T) {
+	tests := []struct {
+		name       string
+		schemaJSON string
+		jsonData   string
+		expectTags []string
+	}{
+		{"Alphabetical after type", `{"type": "string", "pattern": "^x", "minLength": 5, "maxLength": 1}`, `"ab"`, []string{"maxLength", "minLength", "pattern"}},
+		{"Enum short-circuits the rest", `{"type": "string", "pattern": "^x", "minLength": 5, "enum": ["xxxxxx"]}`, `"ab"`, []string{"enum"}},
+		{"Type before enum", `{"type": "string", "maxLength": 1, "enum": ["x"]}`, `12`, []string{"type", "enum"}},
+		{"Const before others", `{"maxLength": 1, "const": "a", "pattern": "^a"}`, `"bb"`, []string{"const", "maxLength", "pattern"}},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				result, err := v.ValidateJSON(tt.jsonData, tt.schemaJSON)
+				assert.NoError(t, err)
+				tags := make([]string, 0, len(result.Errors))
+				for _, e := range result.Errors {
+					tags = append(tags, e.Tag)
+				}
+				assert.Equal(t, tt.expectTags, tags)
+			}
+		})
+	}
+
+	schemaJSON := `{
+		"type": "object",
+		"required": ["id", "name"],
+		"properties": {
+			"zip": {"type": "string", "pattern": "^[0-9]+$"},
+			"age": {"type": "integer", "minimum": 0},
+			"email": {"type": "string", "format": "email"},
+			"tags": {"type": "array", "maxItems": 1}
+		},
+		"maxProperties": 3
+	}`
+	jsonData := `{"zip": "abc", "age": -1, "email": "nope", "tags": [1, 2]}`
+	var first []string
+	for i := 0; i < 100; i++ {
+		result, err := v.ValidateJSON(jsonData, schemaJSON)
+		assert.NoError(t, err)
+		seq := make([]string, 0, len(result.Errors))
+		for _, e := range result.Errors {
+			seq = append(seq, e.Path+" "+e.Tag)
+		}
+		if first == nil {
+			first = seq
+			assert.Equal(t, []string{"$.id required", "$.name required", "$.age minimum", "$.email format", "$.tags maxItems", "$.zip pattern", "$ maxProperties"}, seq)
+			continue
+		}
+		assert.Equal(t, first, seq)
+	}
+
+	result, err := New(WithStopOnFirstError(true)).ValidateJSON(`"ab"`, `{"pattern": "^x", "minLength": 5, "maxLength": 1}`)
+	assert.NoError(t, err)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "maxLength", result.Errors[0].Tag)
+	}
+}